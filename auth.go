@@ -0,0 +1,228 @@
+package gosatellite
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	stdsort "sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Name of the cookie holding the session of the Foreman API
+const sessionCookieName = "_session_id"
+
+// Authenticator adds credentials to the requests made to the Red Hat Satellite API.
+// It is called once every request has been fully built, including its query string, and
+// again before every retry of the request.
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// responseObserver is implemented by authenticators that keep state from the responses
+// to their requests, such as a session cookie.
+type responseObserver interface {
+	observeResponse(resp *http.Response)
+}
+
+// BasicAuth authenticates requests with a username and password.
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+var _ Authenticator = &BasicAuth{}
+
+// Authenticate sets the basic authentication header of the request
+func (a *BasicAuth) Authenticate(req *http.Request) error {
+	req.SetBasicAuth(a.Username, a.Password)
+	return nil
+}
+
+// PersonalAccessToken authenticates requests with a Personal Access Token of a user. The
+// token is sent in place of the password of the user.
+type PersonalAccessToken struct {
+	Username string
+	Token    string
+}
+
+var _ Authenticator = &PersonalAccessToken{}
+
+// Authenticate sets the basic authentication header of the request with the token
+func (a *PersonalAccessToken) Authenticate(req *http.Request) error {
+	if a.Token == "" {
+		return NewArgError("PersonalAccessToken.Token", "cannot be empty")
+	}
+
+	req.SetBasicAuth(a.Username, a.Token)
+	return nil
+}
+
+// OAuth1 authenticates requests with the two-legged OAuth 1.0a consumer key and secret
+// configured in the Foreman settings. The requests are signed with HMAC-SHA1 and no token.
+type OAuth1 struct {
+	ConsumerKey    string
+	ConsumerSecret string
+
+	// Login of the user to map the requests to, sent in the FOREMAN-USER header. Only used
+	// when the oauth_map_users setting is enabled.
+	User string
+}
+
+var _ Authenticator = &OAuth1{}
+
+// Authenticate signs the request and sets its OAuth authorization header
+func (a *OAuth1) Authenticate(req *http.Request) error {
+	if a.ConsumerKey == "" {
+		return NewArgError("OAuth1.ConsumerKey", "cannot be empty")
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	oauthParams := map[string]string{
+		"oauth_consumer_key":     a.ConsumerKey,
+		"oauth_nonce":            hex.EncodeToString(nonce),
+		"oauth_signature_method": "HMAC-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(time.Now().Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+
+	oauthParams["oauth_signature"] = a.signature(req, oauthParams)
+
+	names := make([]string, 0, len(oauthParams))
+	for name := range oauthParams {
+		names = append(names, name)
+	}
+	stdsort.Strings(names)
+
+	header := make([]string, 0, len(names))
+	for _, name := range names {
+		header = append(header, fmt.Sprintf("%s=\"%s\"", name, oauthEscape(oauthParams[name])))
+	}
+
+	req.Header.Set("Authorization", "OAuth "+strings.Join(header, ", "))
+	if a.User != "" {
+		req.Header.Set("FOREMAN-USER", a.User)
+	}
+
+	return nil
+}
+
+// signature computes the HMAC-SHA1 signature of the request as described in RFC 5849
+func (a *OAuth1) signature(req *http.Request, oauthParams map[string]string) string {
+	var params []string
+	for name, values := range req.URL.Query() {
+		for _, value := range values {
+			params = append(params, oauthEscape(name)+"="+oauthEscape(value))
+		}
+	}
+	for name, value := range oauthParams {
+		params = append(params, oauthEscape(name)+"="+oauthEscape(value))
+	}
+	stdsort.Strings(params)
+
+	baseURL := *req.URL
+	baseURL.RawQuery = ""
+	baseURL.Fragment = ""
+	baseURL.Scheme = strings.ToLower(baseURL.Scheme)
+	baseURL.Host = strings.ToLower(baseURL.Host)
+	if (baseURL.Scheme == "https" && baseURL.Port() == "443") || (baseURL.Scheme == "http" && baseURL.Port() == "80") {
+		baseURL.Host = baseURL.Hostname()
+	}
+
+	base := strings.Join([]string{
+		strings.ToUpper(req.Method),
+		oauthEscape(baseURL.String()),
+		oauthEscape(strings.Join(params, "&")),
+	}, "&")
+
+	mac := hmac.New(sha1.New, []byte(oauthEscape(a.ConsumerSecret)+"&"))
+	mac.Write([]byte(base))
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// oauthEscape percent-encodes a string, leaving only the unreserved characters of RFC 3986
+func oauthEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+// SessionCookie authenticates requests with the session cookie of the Foreman API. While
+// no session is known the requests are authenticated with Fallback, and the session cookie
+// set by the server in response is reused for the following requests. The session is
+// dropped when the server answers 401 Unauthorized, so that the next request falls back
+// to Fallback again.
+type SessionCookie struct {
+	// Authenticator used while no session is known
+	Fallback Authenticator
+
+	mu      sync.Mutex
+	session string
+}
+
+var _ Authenticator = &SessionCookie{}
+
+// NewSessionCookie creates a session cookie authenticator with an existing session, e.g.
+// one saved by a previous run. The session can be empty.
+func NewSessionCookie(session string, fallback Authenticator) *SessionCookie {
+	return &SessionCookie{Fallback: fallback, session: session}
+}
+
+// Authenticate sets the session cookie of the request, or authenticates it with the
+// fallback authenticator when there is no session
+func (a *SessionCookie) Authenticate(req *http.Request) error {
+	session := a.Session()
+	if session == "" {
+		if a.Fallback == nil {
+			return NewArgError("SessionCookie.Fallback", "cannot be empty without a session")
+		}
+		return a.Fallback.Authenticate(req)
+	}
+
+	req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: session})
+	return nil
+}
+
+// Session returns the current session ID, so that it can be saved for reuse
+func (a *SessionCookie) Session() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.session
+}
+
+func (a *SessionCookie) observeResponse(resp *http.Response) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		a.session = ""
+		return
+	}
+
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == sessionCookieName {
+			a.session = cookie.Value
+		}
+	}
+}
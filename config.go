@@ -0,0 +1,216 @@
+package gosatellite
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Environment variables read by LoadConfig
+const (
+	EnvSatelliteHost      = "SATELLITE_HOST"
+	EnvSatelliteUsername  = "SATELLITE_USERNAME"
+	EnvSatellitePassword  = "SATELLITE_PASSWORD"
+	EnvSatelliteToken     = "SATELLITE_TOKEN"
+	EnvSatelliteCAFile    = "SATELLITE_CA_FILE"
+	EnvSatelliteSSLVerify = "SATELLITE_SSL_VERIFY"
+	EnvSatelliteConfig    = "SATELLITE_CONFIG"
+)
+
+// configSettings are the settings gathered from the configuration files and the environment.
+// A nil field was not set by any source.
+type configSettings struct {
+	host      *string
+	username  *string
+	password  *string
+	token     *string
+	caFile    *string
+	sslVerify *string
+}
+
+// DefaultConfigPaths returns the configuration files read by LoadConfig when no path is
+// given, from lowest to highest precedence: the system and user hammer configuration, then
+// the gosatellite configuration file from SATELLITE_CONFIG or the user configuration
+// directory.
+func DefaultConfigPaths() []string {
+	paths := []string{
+		"/etc/hammer/cli_config.yml",
+		"/etc/hammer/cli.modules.d/foreman.yml",
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths,
+			filepath.Join(home, ".hammer", "cli_config.yml"),
+			filepath.Join(home, ".hammer", "cli.modules.d", "foreman.yml"),
+		)
+	}
+
+	if path := os.Getenv(EnvSatelliteConfig); path != "" {
+		paths = append(paths, path)
+	} else if dir, err := os.UserConfigDir(); err == nil {
+		paths = append(paths, filepath.Join(dir, "gosatellite", "config.yml"))
+	}
+
+	return paths
+}
+
+// LoadConfig builds a Config from configuration files and SATELLITE_* environment variables.
+//
+// The files are read in order, each one overriding the settings of the previous ones, and the
+// environment variables override all of them. When no path is given DefaultConfigPaths is used
+// and missing files are skipped, otherwise every file must exist.
+//
+// Hammer files provide :host:, :username: and :password: in their :foreman: section, and
+// :ssl_ca_file: and :verify_ssl: in their :ssl: section. A gosatellite configuration file uses
+// the same YAML format with the top level keys host, username, password, token, ca_file and
+// ssl_verify. When a token is set it is used as a Personal Access Token instead of the password.
+func LoadConfig(paths ...string) (*Config, error) {
+	explicit := len(paths) > 0
+	if !explicit {
+		paths = DefaultConfigPaths()
+	}
+
+	settings := new(configSettings)
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) && !explicit && path != os.Getenv(EnvSatelliteConfig) {
+				continue
+			}
+			return nil, err
+		}
+
+		values, err := parseSimpleYAML(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", path, err)
+		}
+
+		settings.applyFile(values)
+	}
+
+	settings.applyEnvironment()
+
+	return settings.config()
+}
+
+// NewClientFromEnvironment returns a new Red Hat Satellite API client configured by
+// LoadConfig from the default configuration files and the environment.
+func NewClientFromEnvironment() (*Client, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(config)
+}
+
+// applyFile applies the settings of a hammer or gosatellite configuration file
+func (s *configSettings) applyFile(values map[string]interface{}) {
+	set := func(dst **string, section map[string]interface{}, key string) {
+		if value, ok := section[key].(string); ok {
+			*dst = &value
+		}
+	}
+
+	if foreman, ok := values["foreman"].(map[string]interface{}); ok {
+		set(&s.host, foreman, "host")
+		set(&s.username, foreman, "username")
+		set(&s.password, foreman, "password")
+	}
+
+	if ssl, ok := values["ssl"].(map[string]interface{}); ok {
+		set(&s.caFile, ssl, "ssl_ca_file")
+		set(&s.sslVerify, ssl, "verify_ssl")
+	}
+
+	set(&s.host, values, "host")
+	set(&s.username, values, "username")
+	set(&s.password, values, "password")
+	set(&s.token, values, "token")
+	set(&s.caFile, values, "ca_file")
+	set(&s.sslVerify, values, "ssl_verify")
+}
+
+// applyEnvironment applies the settings of the SATELLITE_* environment variables that are
+// not empty
+func (s *configSettings) applyEnvironment() {
+	set := func(dst **string, name string) {
+		if value := os.Getenv(name); value != "" {
+			*dst = &value
+		}
+	}
+
+	set(&s.host, EnvSatelliteHost)
+	set(&s.username, EnvSatelliteUsername)
+	set(&s.password, EnvSatellitePassword)
+	set(&s.token, EnvSatelliteToken)
+	set(&s.caFile, EnvSatelliteCAFile)
+	set(&s.sslVerify, EnvSatelliteSSLVerify)
+}
+
+// config validates the settings and builds a Config from them
+func (s *configSettings) config() (*Config, error) {
+	value := func(v *string) string {
+		if v == nil {
+			return ""
+		}
+		return strings.TrimSpace(*v)
+	}
+
+	config := &Config{SSLVerify: true}
+
+	host := value(s.host)
+	if host == "" {
+		return nil, NewArgError("Config.SatelliteHost", "cannot be empty, set "+EnvSatelliteHost+" or :host: in the :foreman: section of the hammer configuration")
+	}
+	if strings.Contains(host, "://") {
+		u, err := url.Parse(host)
+		if err != nil {
+			return nil, NewArgError("Config.SatelliteHost", err.Error())
+		}
+		if u.Scheme != "https" {
+			return nil, NewArgError("Config.SatelliteHost", fmt.Sprintf("must use https, not %s", u.Scheme))
+		}
+		host = u.Host
+	}
+	config.SatelliteHost = strings.TrimSuffix(host, "/")
+
+	config.Username = value(s.username)
+	if config.Username == "" {
+		return nil, NewArgError("Config.Username", "cannot be empty, set "+EnvSatelliteUsername+" or :username: in the :foreman: section of the hammer configuration")
+	}
+
+	if token := value(s.token); token != "" {
+		config.Authenticator = &PersonalAccessToken{Username: config.Username, Token: token}
+	} else if s.password != nil && *s.password != "" {
+		config.Password = *s.password
+	} else {
+		return nil, NewArgError("Config.Password", "cannot be empty without a token, set "+EnvSatellitePassword+" or "+EnvSatelliteToken)
+	}
+
+	if sslVerify := value(s.sslVerify); sslVerify != "" {
+		verify, err := strconv.ParseBool(sslVerify)
+		if err != nil {
+			return nil, NewArgError("Config.SSLVerify", fmt.Sprintf("%q is not a boolean", sslVerify))
+		}
+		config.SSLVerify = verify
+	}
+
+	if caFile := value(s.caFile); caFile != "" {
+		if strings.HasPrefix(caFile, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				caFile = filepath.Join(home, caFile[2:])
+			}
+		}
+		if _, err := os.Stat(caFile); err != nil {
+			return nil, NewArgError("Config.CACertFile", err.Error())
+		}
+		config.CACertFile = caFile
+	}
+
+	return config, nil
+}
@@ -0,0 +1,166 @@
+package gosatellite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ContentViewComponent defines model for a component of a composite Content View.
+type ContentViewComponent struct {
+	CompositeContentView *cvvContentView     `json:"composite_content_view"`
+	ContentView          *cvvContentView     `json:"content_view"`
+	ContentViewVersion   *ContentViewVersion `json:"content_view_version"`
+	ID                   *int                `json:"id"`
+	Latest               *bool               `json:"latest"`
+}
+
+// ContentViewComponentsList defines model for a list of Content View Components.
+type ContentViewComponentsList struct {
+	searchResults
+	Results *[]ContentViewComponent `json:"results"`
+}
+
+// ContentViewComponentAdd defines model for adding a component to a composite content view.
+// Either a ContentViewVersionID is pinned, or ContentViewID is given with Latest set to
+// always use the latest version of that content view.
+type ContentViewComponentAdd struct {
+	ContentViewVersionID *int  `json:"content_view_version_id,omitempty"`
+	ContentViewID        *int  `json:"content_view_id,omitempty"`
+	Latest               *bool `json:"latest,omitempty"`
+}
+
+// ContentViewComponentUpdate defines model for updating a component of a composite content view.
+type ContentViewComponentUpdate struct {
+	ContentViewVersionID *int  `json:"content_view_version_id,omitempty"`
+	Latest               *bool `json:"latest,omitempty"`
+}
+
+// ContentViewComponents is an interface for interacting with
+// Red Hat Satellite Content View Components
+type ContentViewComponents interface {
+	Add(ctx context.Context, compositeCVID int, components []ContentViewComponentAdd) (*ContentViewComponentsList, *http.Response, error)
+	Get(ctx context.Context, compositeCVID int, componentID int) (*ContentViewComponent, *http.Response, error)
+	List(ctx context.Context, compositeCVID int, opt *KatelloListOptions) (*ContentViewComponentsList, *http.Response, error)
+	Remove(ctx context.Context, compositeCVID int, componentIDs []int) (*ContentViewComponentsList, *http.Response, error)
+	Update(ctx context.Context, compositeCVID int, componentID int, componentUpdate ContentViewComponentUpdate) (*ContentViewComponent, *http.Response, error)
+}
+
+// ContentViewComponentsOp handles communication with the Content View Component related methods of the
+// Red Hat Satellite REST API
+type ContentViewComponentsOp struct {
+	client *Client
+}
+
+// Add components to a composite content view
+func (s *ContentViewComponentsOp) Add(ctx context.Context, compositeCVID int, components []ContentViewComponentAdd) (*ContentViewComponentsList, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/content_view_components/add", contentViewsPath, compositeCVID)
+
+	if len(components) < 1 {
+		return nil, nil, NewArgError("components", "cannot be empty")
+	}
+
+	for _, component := range components {
+		if component.ContentViewVersionID == nil && component.ContentViewID == nil {
+			return nil, nil, NewArgError("Both component.ContentViewVersionID and component.ContentViewID", "cannot be empty")
+		}
+		if component.ContentViewVersionID == nil && (component.Latest == nil || !*component.Latest) {
+			return nil, nil, NewArgError("component.Latest", "must be true when no content view version is pinned")
+		}
+		if component.ContentViewVersionID != nil && component.Latest != nil && *component.Latest {
+			return nil, nil, NewArgError("component.Latest", "cannot be true when a content view version is pinned")
+		}
+	}
+
+	var body struct {
+		Components []ContentViewComponentAdd `json:"components"`
+	}
+
+	body.Components = components
+
+	return s.listRequest(ctx, http.MethodPut, path, body)
+}
+
+// Get a single component of a composite content view by its ID
+func (s *ContentViewComponentsOp) Get(ctx context.Context, compositeCVID int, componentID int) (*ContentViewComponent, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/content_view_components/%d", contentViewsPath, compositeCVID, componentID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	component := new(ContentViewComponent)
+	resp, err := s.client.Do(ctx, req, component)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return component, resp, err
+}
+
+// List the components of a composite content view
+func (s *ContentViewComponentsOp) List(ctx context.Context, compositeCVID int, opt *KatelloListOptions) (*ContentViewComponentsList, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/content_view_components", contentViewsPath, compositeCVID)
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.listRequest(ctx, http.MethodGet, path, nil)
+}
+
+// Performs a request given a path that returns a list of components.
+func (s *ContentViewComponentsOp) listRequest(ctx context.Context, method, path string, body interface{}) (*ContentViewComponentsList, *http.Response, error) {
+	req, err := s.client.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(ContentViewComponentsList)
+	resp, err := s.client.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, err
+}
+
+// Remove components from a composite content view
+func (s *ContentViewComponentsOp) Remove(ctx context.Context, compositeCVID int, componentIDs []int) (*ContentViewComponentsList, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/content_view_components/remove", contentViewsPath, compositeCVID)
+
+	if len(componentIDs) < 1 {
+		return nil, nil, NewArgError("componentIDs", "cannot be empty")
+	}
+
+	var body struct {
+		ComponentIDs []int `json:"component_ids"`
+	}
+
+	body.ComponentIDs = componentIDs
+
+	return s.listRequest(ctx, http.MethodPut, path, body)
+}
+
+// Update a component of a composite content view
+func (s *ContentViewComponentsOp) Update(ctx context.Context, compositeCVID int, componentID int, componentUpdate ContentViewComponentUpdate) (*ContentViewComponent, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/content_view_components/%d", contentViewsPath, compositeCVID, componentID)
+
+	if componentUpdate.ContentViewVersionID != nil && componentUpdate.Latest != nil && *componentUpdate.Latest {
+		return nil, nil, NewArgError("componentUpdate.Latest", "cannot be true when a content view version is pinned")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPut, path, componentUpdate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	component := new(ContentViewComponent)
+	resp, err := s.client.Do(ctx, req, component)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return component, resp, err
+}
@@ -0,0 +1,487 @@
+package gosatellite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const contentViewFiltersPath = katelloBasePath + "/content_view_filters"
+
+// Types of content view filters
+const (
+	ContentViewFilterTypeRPM          = "rpm"
+	ContentViewFilterTypePackageGroup = "package_group"
+	ContentViewFilterTypeErratum      = "erratum"
+	ContentViewFilterTypeModuleStream = "modulemd"
+	ContentViewFilterTypeDocker       = "docker"
+)
+
+// ContentViewFilter defines model for a Content View Filter.
+type ContentViewFilter struct {
+	ContentView           *cvvContentView          `json:"content_view"`
+	CreatedAt             *string                  `json:"created_at"`
+	Description           *string                  `json:"description"`
+	ID                    *int                     `json:"id"`
+	Inclusion             *bool                    `json:"inclusion"`
+	Name                  *string                  `json:"name"`
+	OriginalModuleStreams *bool                    `json:"original_module_streams"`
+	OriginalPackages      *bool                    `json:"original_packages"`
+	Repositories          *[]shortRepository       `json:"repositories"`
+	Rules                 *[]ContentViewFilterRule `json:"rules"`
+	Type                  *string                  `json:"type"`
+	UpdatedAt             *string                  `json:"updated_at"`
+}
+
+// ContentViewFilterRule defines model for a rule of a Content View Filter. Which fields
+// are set depends on the type of the filter the rule belongs to.
+type ContentViewFilterRule struct {
+	AllowOtherTypes     *bool     `json:"allow_other_types"`
+	Architecture        *string   `json:"architecture"`
+	ContentViewFilterID *int      `json:"content_view_filter_id"`
+	CreatedAt           *string   `json:"created_at"`
+	DateType            *string   `json:"date_type"`
+	EndDate             *string   `json:"end_date"`
+	ErrataID            *string   `json:"errata_id"`
+	ID                  *int      `json:"id"`
+	MaxVersion          *string   `json:"max_version"`
+	MinVersion          *string   `json:"min_version"`
+	ModuleStreamID      *int      `json:"module_stream_id"`
+	Name                *string   `json:"name"`
+	StartDate           *string   `json:"start_date"`
+	Types               *[]string `json:"types"`
+	UpdatedAt           *string   `json:"updated_at"`
+	UUID                *string   `json:"uuid"`
+	Version             *string   `json:"version"`
+}
+
+// ContentViewFiltersList defines model for a list of Content View Filters.
+type ContentViewFiltersList struct {
+	searchResults
+	Results *[]ContentViewFilter `json:"results"`
+}
+
+// ContentViewFilterRulesList defines model for a list of Content View Filter Rules.
+type ContentViewFilterRulesList struct {
+	searchResults
+	Results *[]ContentViewFilterRule `json:"results"`
+}
+
+// ContentViewFiltersListOptions specifies the optional parameters to various List methods that
+// support pagination.
+type ContentViewFiltersListOptions struct {
+	KatelloListOptions
+
+	// Filter by content view
+	ContentViewID int `url:"content_view_id,omitempty"`
+
+	// Filter by name
+	Name string `url:"name,omitempty"`
+
+	// Filter by type
+	Types []string `url:"types,omitempty"`
+}
+
+// ContentViewFilterCreate defines model for creating a content view filter.
+type ContentViewFilterCreate struct {
+	ContentViewID         *int    `json:"content_view_id"`
+	Name                  *string `json:"name"`
+	Type                  *string `json:"type"`
+	Description           *string `json:"description,omitempty"`
+	Inclusion             *bool   `json:"inclusion,omitempty"`
+	OriginalPackages      *bool   `json:"original_packages,omitempty"`
+	OriginalModuleStreams *bool   `json:"original_module_streams,omitempty"`
+	RepositoryIDs         *[]int  `json:"repository_ids,omitempty"`
+}
+
+// ContentViewFilterUpdate defines model for updating a content view filter.
+type ContentViewFilterUpdate struct {
+	Name                  *string `json:"name,omitempty"`
+	Description           *string `json:"description,omitempty"`
+	Inclusion             *bool   `json:"inclusion,omitempty"`
+	OriginalPackages      *bool   `json:"original_packages,omitempty"`
+	OriginalModuleStreams *bool   `json:"original_module_streams,omitempty"`
+	RepositoryIDs         *[]int  `json:"repository_ids,omitempty"`
+}
+
+// ContentViewFilterRuleBody is implemented by the typed rules that can be created or updated
+// on a content view filter. Each implementation matches one type of filter.
+type ContentViewFilterRuleBody interface {
+	// FilterType returns the type of filter the rule can be added to
+	FilterType() string
+
+	validate() error
+}
+
+// ContentViewFilterRPMRule defines model for a rule of an rpm filter. Either an exact
+// Version or a MinVersion and/or MaxVersion range can be given.
+type ContentViewFilterRPMRule struct {
+	Name         *string `json:"name,omitempty"`
+	Version      *string `json:"version,omitempty"`
+	MinVersion   *string `json:"min_version,omitempty"`
+	MaxVersion   *string `json:"max_version,omitempty"`
+	Architecture *string `json:"architecture,omitempty"`
+}
+
+// FilterType of an rpm rule
+func (r ContentViewFilterRPMRule) FilterType() string {
+	return ContentViewFilterTypeRPM
+}
+
+func (r ContentViewFilterRPMRule) validate() error {
+	if r.Version != nil && (r.MinVersion != nil || r.MaxVersion != nil) {
+		return NewArgError("rule.Version", "cannot be combined with rule.MinVersion or rule.MaxVersion")
+	}
+
+	return nil
+}
+
+// ContentViewFilterErratumRule defines model for a rule of an erratum filter. A rule either
+// matches errata by ID or by type within a date window.
+type ContentViewFilterErratumRule struct {
+	ErrataID        *string   `json:"errata_id,omitempty"`
+	ErrataIDs       *[]string `json:"errata_ids,omitempty"`
+	StartDate       *string   `json:"start_date,omitempty"`
+	EndDate         *string   `json:"end_date,omitempty"`
+	Types           *[]string `json:"types,omitempty"`
+	DateType        *string   `json:"date_type,omitempty"`
+	AllowOtherTypes *bool     `json:"allow_other_types,omitempty"`
+}
+
+// FilterType of an erratum rule
+func (r ContentViewFilterErratumRule) FilterType() string {
+	return ContentViewFilterTypeErratum
+}
+
+func (r ContentViewFilterErratumRule) validate() error {
+	byID := r.ErrataID != nil || r.ErrataIDs != nil
+	byDate := r.StartDate != nil || r.EndDate != nil || r.Types != nil || r.DateType != nil
+
+	if byID && byDate {
+		return NewArgError("rule.ErrataID and rule.ErrataIDs", "cannot be combined with a date window or errata types")
+	}
+
+	// Katello creates one rule per erratum and returns them all, add them one at a time
+	if r.ErrataIDs != nil && len(*r.ErrataIDs) > 1 {
+		return NewArgError("rule.ErrataIDs", "cannot hold more than one ID, create one rule per erratum")
+	}
+
+	if r.DateType != nil && *r.DateType != "issued" && *r.DateType != "updated" {
+		return NewArgError("rule.DateType", "must be one of issued or updated")
+	}
+
+	return nil
+}
+
+// ContentViewFilterModuleStreamRule defines model for a rule of a module stream filter.
+type ContentViewFilterModuleStreamRule struct {
+	ModuleStreamIDs *[]int `json:"module_stream_ids,omitempty"`
+}
+
+// FilterType of a module stream rule
+func (r ContentViewFilterModuleStreamRule) FilterType() string {
+	return ContentViewFilterTypeModuleStream
+}
+
+func (r ContentViewFilterModuleStreamRule) validate() error {
+	// Katello creates one rule per module stream and returns them all, add them one at a time
+	if r.ModuleStreamIDs != nil && len(*r.ModuleStreamIDs) > 1 {
+		return NewArgError("rule.ModuleStreamIDs", "cannot hold more than one ID, create one rule per module stream")
+	}
+
+	return nil
+}
+
+// ContentViewFilterPackageGroupRule defines model for a rule of a package group filter.
+type ContentViewFilterPackageGroupRule struct {
+	Name *string `json:"name,omitempty"`
+	UUID *string `json:"uuid,omitempty"`
+}
+
+// FilterType of a package group rule
+func (r ContentViewFilterPackageGroupRule) FilterType() string {
+	return ContentViewFilterTypePackageGroup
+}
+
+func (r ContentViewFilterPackageGroupRule) validate() error {
+	return nil
+}
+
+// ContentViewFilterDockerRule defines model for a rule of a container image tag filter.
+type ContentViewFilterDockerRule struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// FilterType of a container image tag rule
+func (r ContentViewFilterDockerRule) FilterType() string {
+	return ContentViewFilterTypeDocker
+}
+
+func (r ContentViewFilterDockerRule) validate() error {
+	return nil
+}
+
+// ContentViewFilters is an interface for interacting with
+// Red Hat Satellite Content View Filters
+type ContentViewFilters interface {
+	Create(ctx context.Context, filterCreate ContentViewFilterCreate) (*ContentViewFilter, *http.Response, error)
+	CreateRule(ctx context.Context, filterID int, rule ContentViewFilterRuleBody) (*ContentViewFilterRule, *http.Response, error)
+	Delete(ctx context.Context, filterID int) (*http.Response, error)
+	DeleteRule(ctx context.Context, filterID int, ruleID int) (*http.Response, error)
+	Get(ctx context.Context, filterID int) (*ContentViewFilter, *http.Response, error)
+	GetRule(ctx context.Context, filterID int, ruleID int) (*ContentViewFilterRule, *http.Response, error)
+	List(ctx context.Context, opt *ContentViewFiltersListOptions) (*ContentViewFiltersList, *http.Response, error)
+	ListByContentViewID(ctx context.Context, cvID int, opt *ContentViewFiltersListOptions) (*ContentViewFiltersList, *http.Response, error)
+	ListRules(ctx context.Context, filterID int, opt *KatelloListOptions) (*ContentViewFilterRulesList, *http.Response, error)
+	Update(ctx context.Context, filterID int, filterUpdate ContentViewFilterUpdate) (*ContentViewFilter, *http.Response, error)
+	UpdateRule(ctx context.Context, filterID int, ruleID int, rule ContentViewFilterRuleBody) (*ContentViewFilterRule, *http.Response, error)
+}
+
+// ContentViewFiltersOp handles communication with the Content View Filter related methods of the
+// Red Hat Satellite REST API
+type ContentViewFiltersOp struct {
+	client *Client
+}
+
+// Create a new content view filter
+func (s *ContentViewFiltersOp) Create(ctx context.Context, filterCreate ContentViewFilterCreate) (*ContentViewFilter, *http.Response, error) {
+	path := contentViewFiltersPath
+
+	if filterCreate.ContentViewID == nil {
+		return nil, nil, NewArgError("filterCreate.ContentViewID", "cannot be empty")
+	}
+
+	if filterCreate.Name == nil {
+		return nil, nil, NewArgError("filterCreate.Name", "cannot be empty")
+	} else if *filterCreate.Name == "" {
+		return nil, nil, NewArgError("filterCreate.Name", "cannot be empty")
+	}
+
+	if filterCreate.Type == nil {
+		return nil, nil, NewArgError("filterCreate.Type", "cannot be empty")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, filterCreate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter := new(ContentViewFilter)
+	resp, err := s.client.Do(ctx, req, filter)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return filter, resp, err
+}
+
+// CreateRule adds a new rule to a content view filter. The type of the rule must match the
+// type of the filter.
+func (s *ContentViewFiltersOp) CreateRule(ctx context.Context, filterID int, rule ContentViewFilterRuleBody) (*ContentViewFilterRule, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/rules", contentViewFiltersPath, filterID)
+
+	if rule == nil {
+		return nil, nil, NewArgError("rule", "cannot be empty")
+	}
+
+	if err := rule.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if resp, err := s.checkRuleType(ctx, filterID, rule); err != nil {
+		return nil, resp, err
+	}
+
+	return s.ruleRequest(ctx, http.MethodPost, path, rule)
+}
+
+// Delete a content view filter by its ID
+func (s *ContentViewFiltersOp) Delete(ctx context.Context, filterID int) (*http.Response, error) {
+	path := fmt.Sprintf("%s/%d", contentViewFiltersPath, filterID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, err
+}
+
+// DeleteRule deletes a rule from a content view filter
+func (s *ContentViewFiltersOp) DeleteRule(ctx context.Context, filterID int, ruleID int) (*http.Response, error) {
+	path := fmt.Sprintf("%s/%d/rules/%d", contentViewFiltersPath, filterID, ruleID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, err
+}
+
+// Get a single content view filter by its ID
+func (s *ContentViewFiltersOp) Get(ctx context.Context, filterID int) (*ContentViewFilter, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", contentViewFiltersPath, filterID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter := new(ContentViewFilter)
+	resp, err := s.client.Do(ctx, req, filter)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return filter, resp, err
+}
+
+// GetRule gets a single rule of a content view filter by its ID
+func (s *ContentViewFiltersOp) GetRule(ctx context.Context, filterID int, ruleID int) (*ContentViewFilterRule, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/rules/%d", contentViewFiltersPath, filterID, ruleID)
+
+	return s.ruleRequest(ctx, http.MethodGet, path, nil)
+}
+
+// Performs a list request given a path.
+func (s *ContentViewFiltersOp) list(ctx context.Context, path string) (*ContentViewFiltersList, *http.Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(ContentViewFiltersList)
+	resp, err := s.client.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, err
+}
+
+// List all content view filters or a filtered list of content view filters
+func (s *ContentViewFiltersOp) List(ctx context.Context, opt *ContentViewFiltersListOptions) (*ContentViewFiltersList, *http.Response, error) {
+	path := contentViewFiltersPath
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.list(ctx, path)
+}
+
+// ListByContentViewID all filters of a content view or a filtered list of its filters
+func (s *ContentViewFiltersOp) ListByContentViewID(ctx context.Context, cvID int, opt *ContentViewFiltersListOptions) (*ContentViewFiltersList, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/filters", contentViewsPath, cvID)
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.list(ctx, path)
+}
+
+// ListRules lists the rules of a content view filter
+func (s *ContentViewFiltersOp) ListRules(ctx context.Context, filterID int, opt *KatelloListOptions) (*ContentViewFilterRulesList, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/rules", contentViewFiltersPath, filterID)
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rules := new(ContentViewFilterRulesList)
+	resp, err := s.client.Do(ctx, req, rules)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rules, resp, err
+}
+
+// checkRuleType gets a content view filter and checks that the rule matches its type
+func (s *ContentViewFiltersOp) checkRuleType(ctx context.Context, filterID int, rule ContentViewFilterRuleBody) (*http.Response, error) {
+	filter, resp, err := s.Get(ctx, filterID)
+	if err != nil {
+		return resp, err
+	}
+
+	if filter.Type == nil || *filter.Type != rule.FilterType() {
+		return resp, NewArgError("rule", fmt.Sprintf("is a %s rule, filter %d is not a %s filter", rule.FilterType(), filterID, rule.FilterType()))
+	}
+
+	return resp, nil
+}
+
+// Performs a request given a path that returns a single rule.
+func (s *ContentViewFiltersOp) ruleRequest(ctx context.Context, method, path string, body interface{}) (*ContentViewFilterRule, *http.Response, error) {
+	req, err := s.client.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rule := new(ContentViewFilterRule)
+	resp, err := s.client.Do(ctx, req, rule)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rule, resp, err
+}
+
+// Update a content view filter
+func (s *ContentViewFiltersOp) Update(ctx context.Context, filterID int, filterUpdate ContentViewFilterUpdate) (*ContentViewFilter, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", contentViewFiltersPath, filterID)
+
+	if filterUpdate.Name != nil && *filterUpdate.Name == "" {
+		return nil, nil, NewArgError("filterUpdate.Name", "cannot be an empty string")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPut, path, filterUpdate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter := new(ContentViewFilter)
+	resp, err := s.client.Do(ctx, req, filter)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return filter, resp, err
+}
+
+// UpdateRule updates a rule of a content view filter. The type of the rule must match the
+// type of the filter.
+func (s *ContentViewFiltersOp) UpdateRule(ctx context.Context, filterID int, ruleID int, rule ContentViewFilterRuleBody) (*ContentViewFilterRule, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/rules/%d", contentViewFiltersPath, filterID, ruleID)
+
+	if rule == nil {
+		return nil, nil, NewArgError("rule", "cannot be empty")
+	}
+
+	if err := rule.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if resp, err := s.checkRuleType(ctx, filterID, rule); err != nil {
+		return nil, resp, err
+	}
+
+	return s.ruleRequest(ctx, http.MethodPut, path, rule)
+}
@@ -0,0 +1,49 @@
+package gosatellite
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestContentViewFiltersCreateRule(t *testing.T) {
+	created := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/katello/api/content_view_filters/1":
+			w.Write([]byte(`{"id":1,"type":"erratum"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/katello/api/content_view_filters/1/rules":
+			created++
+			w.Write([]byte(`{"id":2,"errata_id":"RHSA-2020:0001"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}, nil)
+	ctx := context.Background()
+
+	rule, _, err := client.ContentViewFilters.CreateRule(ctx, 1, ContentViewFilterErratumRule{ErrataID: String("RHSA-2020:0001")})
+	if err != nil {
+		t.Fatalf("CreateRule returned error: %v", err)
+	}
+	if rule.ID == nil || *rule.ID != 2 {
+		t.Errorf("got rule %v, want ID 2", rule.ID)
+	}
+
+	invalid := map[string]ContentViewFilterRuleBody{
+		"type mismatch":       ContentViewFilterRPMRule{Name: String("bash")},
+		"several errata":      ContentViewFilterErratumRule{ErrataIDs: &[]string{"RHSA-2020:0001", "RHSA-2020:0002"}},
+		"several streams":     ContentViewFilterModuleStreamRule{ModuleStreamIDs: &[]int{1, 2}},
+		"id with date window": ContentViewFilterErratumRule{ErrataID: String("RHSA-2020:0001"), Types: &[]string{"security"}},
+	}
+	for name, rule := range invalid {
+		var argErr *ArgError
+		if _, _, err := client.ContentViewFilters.CreateRule(ctx, 1, rule); !errors.As(err, &argErr) {
+			t.Errorf("%s: got error %v, want an *ArgError", name, err)
+		}
+	}
+
+	if created != 1 {
+		t.Errorf("got %d rules created, want 1", created)
+	}
+}
@@ -0,0 +1,286 @@
+package gosatellite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const contentViewVersionsPath = katelloBasePath + "/content_view_versions"
+const contentExportsPath = katelloBasePath + "/content_exports"
+
+// ContentViewVersion defines model for a Content View Version.
+type ContentViewVersion struct {
+	ActiveHistory                      *[]cvvHistory      `json:"active_history"`
+	AnsibleCollectionCount             *int               `json:"ansible_collection_count"`
+	CompositeContentViewIDs            *[]int             `json:"composite_content_view_ids"`
+	CompositeContentViews              *[]cvvContentView  `json:"composite_content_views"`
+	ContentView                        *cvvContentView    `json:"content_view"`
+	ContentViewID                      *int               `json:"content_view_id"`
+	CreatedAt                          *string            `json:"created_at"`
+	DebCount                           *int               `json:"deb_count"`
+	Default                            *bool              `json:"default"`
+	Description                        *string            `json:"description"`
+	DockerManifestCount                *int               `json:"docker_manifest_count"`
+	DockerTagCount                     *int               `json:"docker_tag_count"`
+	Environments                       *[]shortLE         `json:"environments"`
+	ErrataCounts                       *leErrataCounts    `json:"errata_counts"`
+	FileCount                          *int               `json:"file_count"`
+	ID                                 *int               `json:"id"`
+	LastEvent                          *cvvHistory        `json:"last_event"`
+	Major                              *int               `json:"major"`
+	Minor                              *int               `json:"minor"`
+	ModuleStreamCount                  *int               `json:"module_stream_count"`
+	Name                               *string            `json:"name"`
+	PackageCount                       *int               `json:"package_count"`
+	PublishedInCompositeContentViewIDs *[]int             `json:"published_in_composite_content_view_ids"`
+	Repositories                       *[]shortRepository `json:"repositories"`
+	SRPMCount                          *int               `json:"srpm_count"`
+	UpdatedAt                          *string            `json:"updated_at"`
+	Version                            *string            `json:"version"`
+}
+
+type cvvContentView struct {
+	ID    *int    `json:"id"`
+	Name  *string `json:"name"`
+	Label *string `json:"label"`
+}
+
+type cvvHistory struct {
+	Action      *string          `json:"action"`
+	CreatedAt   *string          `json:"created_at"`
+	Description *string          `json:"description"`
+	Environment *genericShortRef `json:"environment"`
+	ID          *int             `json:"id"`
+	Status      *string          `json:"status"`
+	Task        *Task            `json:"task"`
+	User        *string          `json:"user"`
+}
+
+// ContentViewVersionsList defines model for a list of Content View Versions.
+type ContentViewVersionsList struct {
+	searchResults
+	Results *[]ContentViewVersion `json:"results"`
+}
+
+// ContentViewVersionsListOptions specifies the optional parameters to various List methods that
+// support pagination.
+type ContentViewVersionsListOptions struct {
+	KatelloListOptions
+
+	// Filter versions by content view
+	ContentViewID int `url:"content_view_id,omitempty"`
+
+	// Filter versions by environment
+	EnvironmentID int `url:"environment_id,omitempty"`
+
+	// Filter versions by version number
+	Version string `url:"version,omitempty"`
+
+	// Filter versions that are components in the specified composite version
+	CompositeVersionID int `url:"composite_version_id,omitempty"`
+
+	// Filter composite versions whose publish was triggered by the specified component version
+	TriggeredByID int `url:"triggered_by_id,omitempty"`
+
+	// Filter out default content views
+	Nondefault bool `url:"nondefault,omitempty"`
+}
+
+// ContentExportHistory defines model for the history of a content export.
+type ContentExportHistory struct {
+	ContentViewVersion   *string `json:"content_view_version"`
+	ContentViewVersionID *int    `json:"content_view_version_id"`
+	CreatedAt            *string `json:"created_at"`
+	DestinationServer    *string `json:"destination_server"`
+	ExportType           *string `json:"export_type"`
+	ID                   *int    `json:"id"`
+	Path                 *string `json:"path"`
+	UpdatedAt            *string `json:"updated_at"`
+}
+
+// ContentExportHistoriesList defines model for a list of content export histories.
+type ContentExportHistoriesList struct {
+	searchResults
+	Results *[]ContentExportHistory `json:"results"`
+}
+
+// ContentViewVersionIncrementalUpdate defines model for adding content to existing content view
+// versions, creating new minor versions of them.
+type ContentViewVersionIncrementalUpdate struct {
+	ContentViewVersionEnvironments *[]ContentViewVersionEnvironments `json:"content_view_version_environments"`
+	Description                    *string                           `json:"description,omitempty"`
+	ResolveDependencies            *bool                             `json:"resolve_dependencies,omitempty"`
+	PropagateAllComposites         *bool                             `json:"propagate_all_composites,omitempty"`
+	AddContent                     *struct {
+		ErrataIDs  *[]string `json:"errata_ids,omitempty"`
+		PackageIDs *[]int    `json:"package_ids,omitempty"`
+		DebIDs     *[]int    `json:"deb_ids,omitempty"`
+	} `json:"add_content,omitempty"`
+	UpdateHosts *struct {
+		Included struct {
+			IDs    *[]int  `json:"ids,omitempty"`
+			Search *string `json:"search,omitempty"`
+		} `json:"included"`
+	} `json:"update_hosts,omitempty"`
+}
+
+// ContentViewVersionEnvironments defines model for a content view version and the
+// environments it should be updated in.
+type ContentViewVersionEnvironments struct {
+	ContentViewVersionID *int   `json:"content_view_version_id"`
+	EnvironmentIDs       *[]int `json:"environment_ids,omitempty"`
+}
+
+// ContentViewVersions is an interface for interacting with
+// Red Hat Satellite Content View Versions
+type ContentViewVersions interface {
+	Delete(ctx context.Context, cvvID int) (*Task, *http.Response, error)
+	Get(ctx context.Context, cvvID int) (*ContentViewVersion, *http.Response, error)
+	IncrementalUpdate(ctx context.Context, update ContentViewVersionIncrementalUpdate) (*Task, *http.Response, error)
+	List(ctx context.Context, opt *ContentViewVersionsListOptions) (*ContentViewVersionsList, *http.Response, error)
+	ListByContentViewID(ctx context.Context, cvID int, opt *ContentViewVersionsListOptions) (*ContentViewVersionsList, *http.Response, error)
+	ListExportHistory(ctx context.Context, cvvID int, opt *ListOptions) (*ContentExportHistoriesList, *http.Response, error)
+	Promote(ctx context.Context, cvvID int, cvPromote ContentViewPromote) (*Task, *http.Response, error)
+	RepublishRepositories(ctx context.Context, cvvID int) (*Task, *http.Response, error)
+}
+
+// ContentViewVersionsOp handles communication with the Content View Version related methods of the
+// Red Hat Satellite REST API
+type ContentViewVersionsOp struct {
+	client *Client
+}
+
+// Delete a content view version by its ID. The version must not be in any lifecycle environment.
+func (s *ContentViewVersionsOp) Delete(ctx context.Context, cvvID int) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", contentViewVersionsPath, cvvID)
+
+	return s.taskRequest(ctx, http.MethodDelete, path, nil)
+}
+
+// Get a single content view version by its ID
+func (s *ContentViewVersionsOp) Get(ctx context.Context, cvvID int) (*ContentViewVersion, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", contentViewVersionsPath, cvvID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	version := new(ContentViewVersion)
+	resp, err := s.client.Do(ctx, req, version)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return version, resp, err
+}
+
+// IncrementalUpdate adds errata or packages to existing content view versions
+func (s *ContentViewVersionsOp) IncrementalUpdate(ctx context.Context, update ContentViewVersionIncrementalUpdate) (*Task, *http.Response, error) {
+	path := contentViewVersionsPath + "/incremental_update"
+
+	if update.ContentViewVersionEnvironments == nil || len(*update.ContentViewVersionEnvironments) < 1 {
+		return nil, nil, NewArgError("update.ContentViewVersionEnvironments", "cannot be empty")
+	}
+
+	if update.AddContent == nil {
+		return nil, nil, NewArgError("update.AddContent", "cannot be empty")
+	}
+
+	return s.taskRequest(ctx, http.MethodPost, path, update)
+}
+
+// Performs a list request given a path.
+func (s *ContentViewVersionsOp) list(ctx context.Context, path string) (*ContentViewVersionsList, *http.Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(ContentViewVersionsList)
+	resp, err := s.client.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, err
+}
+
+// List all content view versions or a filtered list of content view versions
+func (s *ContentViewVersionsOp) List(ctx context.Context, opt *ContentViewVersionsListOptions) (*ContentViewVersionsList, *http.Response, error) {
+	path := contentViewVersionsPath
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.list(ctx, path)
+}
+
+// ListByContentViewID all versions of a content view or a filtered list of its versions
+func (s *ContentViewVersionsOp) ListByContentViewID(ctx context.Context, cvID int, opt *ContentViewVersionsListOptions) (*ContentViewVersionsList, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/content_view_versions", contentViewsPath, cvID)
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.list(ctx, path)
+}
+
+// ListExportHistory lists the export history of a content view version
+func (s *ContentViewVersionsOp) ListExportHistory(ctx context.Context, cvvID int, opt *ListOptions) (*ContentExportHistoriesList, *http.Response, error) {
+	path := fmt.Sprintf("%s?content_view_version_id=%d", contentExportsPath, cvvID)
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	histories := new(ContentExportHistoriesList)
+	resp, err := s.client.Do(ctx, req, histories)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return histories, resp, err
+}
+
+// Promote a content view version to one or more lifecycle environments
+func (s *ContentViewVersionsOp) Promote(ctx context.Context, cvvID int, cvPromote ContentViewPromote) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/promote", contentViewVersionsPath, cvvID)
+
+	if cvPromote.EnvironmentIDs == nil || len(*cvPromote.EnvironmentIDs) < 1 {
+		return nil, nil, NewArgError("cvPromote.EnvironmentIDs", "cannot be empty")
+	}
+
+	return s.taskRequest(ctx, http.MethodPost, path, cvPromote)
+}
+
+// RepublishRepositories forces the metadata of the repositories in a content view version to be regenerated
+func (s *ContentViewVersionsOp) RepublishRepositories(ctx context.Context, cvvID int) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/republish_repositories", contentViewVersionsPath, cvvID)
+
+	return s.taskRequest(ctx, http.MethodPut, path, nil)
+}
+
+// Performs a request given a path that returns a task.
+func (s *ContentViewVersionsOp) taskRequest(ctx context.Context, method, path string, body interface{}) (*Task, *http.Response, error) {
+	req, err := s.client.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	task := new(Task)
+	resp, err := s.client.Do(ctx, req, task)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return task, resp, err
+}
@@ -37,33 +37,33 @@ type ContentViewsListOptions struct {
 
 // ContentView defines model for a Content View.
 type ContentView struct {
-	Composite              *bool              `json:"composite"`
-	ComponentIDs           *[]int             `json:"component_ids"`
-	Default                *bool              `json:"default"`
-	ForcePuppetEnvironment *bool              `json:"force_puppet_environment"`
-	VersionCount           *int               `json:"version_count"`
-	LatestVersion          *string            `json:"latest_version"`
-	AutoPublish            *bool              `json:"auto_publish"`
-	SolveDependencies      *bool              `json:"solve_dependencies"`
-	RepositoryIDs          *[]int             `json:"repository_ids"`
-	ID                     *int               `json:"id"`
-	Name                   *string            `json:"name"`
-	Label                  *string            `json:"label"`
-	Description            *string            `json:"description"`
-	OrganizationID         *int               `json:"organization_id"`
-	Organization           *shortOrg          `json:"organization"`
-	CreatedAt              *string            `json:"created_at"`
-	UpdatedAt              *string            `json:"updated_at"`
-	Environments           *[]shortLE         `json:"environments"`
-	Repositories           *[]shortRepository `json:"repositories"`
-	Versions               *[]cvVersions      `json:"versions"`
-	ActivationKeys         *[]genericShortRef `json:"activation_keys"`
-	NextVersion            *string            `json:"next_version"`
-	LastPublished          *string            `json:"last_published"`
-	Permissions            *cvPermissions     `json:"permissions"`
+	Composite              *bool                   `json:"composite"`
+	ComponentIDs           *[]int                  `json:"component_ids"`
+	Default                *bool                   `json:"default"`
+	ForcePuppetEnvironment *bool                   `json:"force_puppet_environment"`
+	VersionCount           *int                    `json:"version_count"`
+	LatestVersion          *string                 `json:"latest_version"`
+	AutoPublish            *bool                   `json:"auto_publish"`
+	SolveDependencies      *bool                   `json:"solve_dependencies"`
+	RepositoryIDs          *[]int                  `json:"repository_ids"`
+	ID                     *int                    `json:"id"`
+	Name                   *string                 `json:"name"`
+	Label                  *string                 `json:"label"`
+	Description            *string                 `json:"description"`
+	OrganizationID         *int                    `json:"organization_id"`
+	Organization           *shortOrg               `json:"organization"`
+	CreatedAt              *string                 `json:"created_at"`
+	UpdatedAt              *string                 `json:"updated_at"`
+	Environments           *[]shortLE              `json:"environments"`
+	Repositories           *[]shortRepository      `json:"repositories"`
+	Versions               *[]cvVersions           `json:"versions"`
+	ActivationKeys         *[]genericShortRef      `json:"activation_keys"`
+	NextVersion            *string                 `json:"next_version"`
+	LastPublished          *string                 `json:"last_published"`
+	Permissions            *cvPermissions          `json:"permissions"`
+	Components             *[]ContentViewVersion   `json:"components"`
+	ContentViewComponents  *[]ContentViewComponent `json:"content_view_components"`
 	//PuppetModules          *[]unknown         `json:"puppet_modules"`
-	//Components             *[]unknown         `json:"components"`
-	//ContentViewComponents  *[]unknown         `json:"content_view_components"`
 }
 
 type cvVersions struct {
@@ -87,11 +87,78 @@ type ContentViewsList struct {
 	Results *[]ContentView `json:"results"`
 }
 
+// ContentViewCreate defines model for creating a content view.
+type ContentViewCreate struct {
+	OrganizationID    *int    `json:"organization_id"`
+	Name              *string `json:"name"`
+	Label             *string `json:"label,omitempty"`
+	Description       *string `json:"description,omitempty"`
+	Composite         *bool   `json:"composite,omitempty"`
+	RepositoryIDs     *[]int  `json:"repository_ids,omitempty"`
+	ComponentIDs      *[]int  `json:"component_ids,omitempty"`
+	AutoPublish       *bool   `json:"auto_publish,omitempty"`
+	SolveDependencies *bool   `json:"solve_dependencies,omitempty"`
+}
+
+// ContentViewUpdate defines model for updating a content view.
+type ContentViewUpdate struct {
+	Name              *string `json:"name,omitempty"`
+	Description       *string `json:"description,omitempty"`
+	RepositoryIDs     *[]int  `json:"repository_ids,omitempty"`
+	ComponentIDs      *[]int  `json:"component_ids,omitempty"`
+	AutoPublish       *bool   `json:"auto_publish,omitempty"`
+	SolveDependencies *bool   `json:"solve_dependencies,omitempty"`
+}
+
+// ContentViewCopy defines model for copying a content view.
+type ContentViewCopy struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description,omitempty"`
+}
+
+// ContentViewPublish defines model for publishing a new version of a content view.
+type ContentViewPublish struct {
+	Description    *string `json:"description,omitempty"`
+	Major          *int    `json:"major,omitempty"`
+	Minor          *int    `json:"minor,omitempty"`
+	EnvironmentIDs *[]int  `json:"environment_ids,omitempty"`
+	IsForcePromote *bool   `json:"is_force_promote,omitempty"`
+}
+
+// ContentViewPromote defines model for promoting a content view version to lifecycle environments.
+type ContentViewPromote struct {
+	EnvironmentIDs *[]int  `json:"environment_ids"`
+	Description    *string `json:"description,omitempty"`
+	Force          *bool   `json:"force,omitempty"`
+}
+
+// ContentViewRemove defines model for removing versions of a content view from lifecycle
+// environments and deleting them. Activation keys and hosts using the content being removed
+// are reassigned to the given key and system content view and environment.
+type ContentViewRemove struct {
+	EnvironmentIDs        *[]int `json:"environment_ids,omitempty"`
+	ContentViewVersionIDs *[]int `json:"content_view_version_ids,omitempty"`
+	KeyContentViewID      *int   `json:"key_content_view_id,omitempty"`
+	KeyEnvironmentID      *int   `json:"key_environment_id,omitempty"`
+	SystemContentViewID   *int   `json:"system_content_view_id,omitempty"`
+	SystemEnvironmentID   *int   `json:"system_environment_id,omitempty"`
+	DestroyContentView    *bool  `json:"destroy_content_view,omitempty"`
+}
+
 // ContentViews is an interface for interacting with
 // Red Hat Satellite Content Views
 type ContentViews interface {
+	Copy(ctx context.Context, cvID int, cvCopy ContentViewCopy) (*ContentView, *http.Response, error)
+	Create(ctx context.Context, cvCreate ContentViewCreate) (*ContentView, *http.Response, error)
+	Delete(ctx context.Context, cvID int) (*Task, *http.Response, error)
+	Get(ctx context.Context, cvID int) (*ContentView, *http.Response, error)
 	List(ctx context.Context, opt *ContentViewsListOptions) (*ContentViewsList, *http.Response, error)
 	ListByOrganizationID(ctx context.Context, orgID int, opt *ContentViewsListOptions) (*ContentViewsList, *http.Response, error)
+	Promote(ctx context.Context, cvVersionID int, cvPromote ContentViewPromote) (*Task, *http.Response, error)
+	Publish(ctx context.Context, cvID int, cvPublish ContentViewPublish) (*Task, *http.Response, error)
+	Remove(ctx context.Context, cvID int, cvRemove ContentViewRemove) (*Task, *http.Response, error)
+	RemoveFromEnvironment(ctx context.Context, cvID int, envID int) (*Task, *http.Response, error)
+	Update(ctx context.Context, cvID int, cvUpdate ContentViewUpdate) (*ContentView, *http.Response, error)
 }
 
 // ContentViewsOp handles communication with the Content Views related methods of the
@@ -100,6 +167,84 @@ type ContentViewsOp struct {
 	client *Client
 }
 
+// Copy a content view to a new content view with the given name
+func (s *ContentViewsOp) Copy(ctx context.Context, cvID int, cvCopy ContentViewCopy) (*ContentView, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/copy", contentViewsPath, cvID)
+
+	if cvCopy.Name == nil {
+		return nil, nil, NewArgError("cvCopy.Name", "cannot be empty")
+	} else if *cvCopy.Name == "" {
+		return nil, nil, NewArgError("cvCopy.Name", "cannot be empty")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, cvCopy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contentView := new(ContentView)
+	resp, err := s.client.Do(ctx, req, contentView)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return contentView, resp, err
+}
+
+// Create a new content view
+func (s *ContentViewsOp) Create(ctx context.Context, cvCreate ContentViewCreate) (*ContentView, *http.Response, error) {
+	path := contentViewsPath
+
+	if cvCreate.OrganizationID == nil {
+		return nil, nil, NewArgError("cvCreate.OrganizationID", "cannot be empty")
+	}
+
+	if cvCreate.Name == nil {
+		return nil, nil, NewArgError("cvCreate.Name", "cannot be empty")
+	} else if *cvCreate.Name == "" {
+		return nil, nil, NewArgError("cvCreate.Name", "cannot be empty")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, cvCreate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contentView := new(ContentView)
+	resp, err := s.client.Do(ctx, req, contentView)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return contentView, resp, err
+}
+
+// Delete a content view by its ID. The content view must not be in any lifecycle environment.
+// The returned task tracks the removal.
+func (s *ContentViewsOp) Delete(ctx context.Context, cvID int) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", contentViewsPath, cvID)
+
+	return s.taskRequest(ctx, http.MethodDelete, path, nil)
+}
+
+// Get a single content view by its ID
+func (s *ContentViewsOp) Get(ctx context.Context, cvID int) (*ContentView, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", contentViewsPath, cvID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contentView := new(ContentView)
+	resp, err := s.client.Do(ctx, req, contentView)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return contentView, resp, err
+}
+
 // Performs a list request given a path.
 func (s *ContentViewsOp) list(ctx context.Context, path string) (*ContentViewsList, *http.Response, error) {
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
@@ -137,3 +282,76 @@ func (s *ContentViewsOp) ListByOrganizationID(ctx context.Context, orgID int, op
 
 	return s.list(ctx, path)
 }
+
+// Promote a content view version to one or more lifecycle environments. It is the same as
+// ContentViewVersions.Promote.
+func (s *ContentViewsOp) Promote(ctx context.Context, cvVersionID int, cvPromote ContentViewPromote) (*Task, *http.Response, error) {
+	return s.client.ContentViewVersions.Promote(ctx, cvVersionID, cvPromote)
+}
+
+// Publish a new version of a content view
+func (s *ContentViewsOp) Publish(ctx context.Context, cvID int, cvPublish ContentViewPublish) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/publish", contentViewsPath, cvID)
+
+	if (cvPublish.Major == nil) != (cvPublish.Minor == nil) {
+		return nil, nil, NewArgError("cvPublish.Major and cvPublish.Minor", "must be set together")
+	}
+
+	return s.taskRequest(ctx, http.MethodPost, path, cvPublish)
+}
+
+// Remove versions of a content view from lifecycle environments and optionally delete them
+func (s *ContentViewsOp) Remove(ctx context.Context, cvID int, cvRemove ContentViewRemove) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/remove", contentViewsPath, cvID)
+
+	if cvRemove.EnvironmentIDs == nil && cvRemove.ContentViewVersionIDs == nil {
+		return nil, nil, NewArgError("Both cvRemove.EnvironmentIDs and cvRemove.ContentViewVersionIDs", "cannot be empty")
+	}
+
+	return s.taskRequest(ctx, http.MethodPut, path, cvRemove)
+}
+
+// RemoveFromEnvironment removes a content view from a single lifecycle environment
+func (s *ContentViewsOp) RemoveFromEnvironment(ctx context.Context, cvID int, envID int) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/environments/%d", contentViewsPath, cvID, envID)
+
+	return s.taskRequest(ctx, http.MethodDelete, path, nil)
+}
+
+// Performs a request given a path that returns a task.
+func (s *ContentViewsOp) taskRequest(ctx context.Context, method, path string, body interface{}) (*Task, *http.Response, error) {
+	req, err := s.client.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	task := new(Task)
+	resp, err := s.client.Do(ctx, req, task)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return task, resp, err
+}
+
+// Update a content view
+func (s *ContentViewsOp) Update(ctx context.Context, cvID int, cvUpdate ContentViewUpdate) (*ContentView, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", contentViewsPath, cvID)
+
+	if cvUpdate.Name != nil && *cvUpdate.Name == "" {
+		return nil, nil, NewArgError("cvUpdate.Name", "cannot be an empty string")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPut, path, cvUpdate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contentView := new(ContentView)
+	resp, err := s.client.Do(ctx, req, contentView)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return contentView, resp, err
+}
@@ -0,0 +1,178 @@
+package gosatellite
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a parsed five field cron expression as used by the custom cron
+// interval of sync plans: minute, hour, day of month, month and day of week.
+type cronSchedule struct {
+	minutes  map[int]bool
+	hours    map[int]bool
+	days     map[int]bool
+	months   map[int]bool
+	weekdays map[int]bool
+
+	// Whether the day of month and day of week fields were restricted. When both are,
+	// a day matches if either of them matches.
+	daysRestricted     bool
+	weekdaysRestricted bool
+}
+
+var cronMonthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var cronWeekdayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// parseCron parses a five field cron expression
+func parseCron(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields, found %d", expr, len(fields))
+	}
+
+	var err error
+	c := new(cronSchedule)
+
+	if c.minutes, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if c.hours, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if c.days, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, err
+	}
+	if c.months, err = parseCronField(fields[3], 1, 12, cronMonthNames); err != nil {
+		return nil, err
+	}
+	if c.weekdays, err = parseCronField(fields[4], 0, 7, cronWeekdayNames); err != nil {
+		return nil, err
+	}
+
+	// Both 0 and 7 mean Sunday
+	if c.weekdays[7] {
+		c.weekdays[0] = true
+	}
+
+	c.daysRestricted = !strings.HasPrefix(fields[2], "*")
+	c.weekdaysRestricted = !strings.HasPrefix(fields[4], "*")
+
+	return c, nil
+}
+
+// parseCronField parses a comma separated list of values, ranges and steps
+func parseCronField(field string, min, max int, names map[string]int) (map[int]bool, error) {
+	values := make(map[int]bool)
+
+	for _, part := range strings.Split(field, ",") {
+		step, hasStep := 1, false
+		if i := strings.Index(part, "/"); i >= 0 {
+			hasStep = true
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid step in cron field %q", field)
+			}
+			part = part[:i]
+		}
+
+		start, end := min, max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if start, err = parseCronValue(bounds[0], names); err != nil {
+				return nil, err
+			}
+			if end, err = parseCronValue(bounds[1], names); err != nil {
+				return nil, err
+			}
+		default:
+			value, err := parseCronValue(part, names)
+			if err != nil {
+				return nil, err
+			}
+			start = value
+			if !hasStep {
+				end = value
+			}
+		}
+
+		if start < min || end > max || start > end {
+			return nil, fmt.Errorf("cron field %q is out of range %d-%d", field, min, max)
+		}
+
+		for v := start; v <= end; v += step {
+			values[v] = true
+		}
+	}
+
+	return values, nil
+}
+
+func parseCronValue(value string, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToLower(value)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cron value %q", value)
+	}
+
+	return v, nil
+}
+
+// next returns the first time matching the schedule strictly after t
+func (c *cronSchedule) next(t time.Time) (time.Time, bool) {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	// A matching day is always found within a few years unless the expression can never
+	// match, e.g. the 31st of February.
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if !c.months[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !c.hours[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !c.minutes[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t, true
+	}
+
+	return time.Time{}, false
+}
+
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	day := c.days[t.Day()]
+	weekday := c.weekdays[int(t.Weekday())]
+
+	if c.daysRestricted && c.weekdaysRestricted {
+		return day || weekday
+	}
+
+	return day && weekday
+}
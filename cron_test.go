@@ -0,0 +1,91 @@
+package gosatellite
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	date := func(year int, month time.Month, day, hour, min int) time.Time {
+		return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"minute step", "*/15 * * * *", date(2024, 1, 1, 10, 7), date(2024, 1, 1, 10, 15)},
+		{"minute step into next hour", "*/15 * * * *", date(2024, 1, 1, 10, 45), date(2024, 1, 1, 11, 0)},
+		{"strictly after", "0 * * * *", date(2024, 1, 1, 10, 0), date(2024, 1, 1, 11, 0)},
+		{"seconds truncated", "0 * * * *", date(2024, 1, 1, 10, 59).Add(30 * time.Second), date(2024, 1, 1, 11, 0)},
+		{"hour step", "0 */6 * * *", date(2024, 1, 1, 7, 0), date(2024, 1, 1, 12, 0)},
+		{"hour range into next day", "30 9-17 * * *", date(2024, 1, 1, 17, 31), date(2024, 1, 2, 9, 30)},
+		{"list", "0,20,40 * * * *", date(2024, 1, 1, 10, 21), date(2024, 1, 1, 10, 40)},
+		{"range with step", "10-50/20 * * * *", date(2024, 1, 1, 10, 31), date(2024, 1, 1, 10, 50)},
+		{"value with step", "0 0 */10 * *", date(2024, 1, 11, 0, 0), date(2024, 1, 21, 0, 0)},
+		{"month rollover", "0 0 1 * *", date(2024, 1, 31, 12, 0), date(2024, 2, 1, 0, 0)},
+		{"short month skipped", "0 0 31 * *", date(2024, 1, 31, 0, 0), date(2024, 3, 31, 0, 0)},
+		{"year rollover", "0 12 * 12 *", date(2024, 12, 31, 13, 0), date(2025, 12, 1, 12, 0)},
+		{"month names", "5 4 * jan-mar *", date(2024, 3, 31, 4, 5), date(2025, 1, 1, 4, 5)},
+		{"leap day", "0 0 29 2 *", date(2024, 3, 1, 0, 0), date(2028, 2, 29, 0, 0)},
+		{"day of week", "0 0 * * 0", date(2024, 1, 1, 0, 0), date(2024, 1, 7, 0, 0)},
+		{"day of week 7 is sunday", "0 0 * * 7", date(2024, 1, 1, 0, 0), date(2024, 1, 7, 0, 0)},
+		{"day of week names", "0 0 * * mon-fri", date(2024, 1, 5, 12, 0), date(2024, 1, 8, 0, 0)},
+		{"day of month or day of week", "0 0 1 * mon", date(2024, 1, 1, 0, 0), date(2024, 1, 8, 0, 0)},
+		{"day of month range or day of week", "0 0 1-7 * 5", date(2024, 1, 7, 1, 0), date(2024, 1, 12, 0, 0)},
+		{"day of month with any day of week", "0 0 15 * *", date(2024, 1, 1, 0, 0), date(2024, 1, 15, 0, 0)},
+		{"day of week with any day of month", "0 0 * * fri", date(2024, 1, 1, 0, 0), date(2024, 1, 5, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := parseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("parseCron(%q) returned error: %v", tt.expr, err)
+			}
+
+			got, ok := schedule.next(tt.from)
+			if !ok {
+				t.Fatalf("next(%v) found no match", tt.from)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("next(%v) = %v, want %v", tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNextNeverMatches(t *testing.T) {
+	schedule, err := parseCron("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, ok := schedule.next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Errorf("next = %v, want no match", got)
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	tests := []string{
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"x * * * *",
+		"* * * foo *",
+	}
+
+	for _, expr := range tests {
+		if _, err := parseCron(expr); err == nil {
+			t.Errorf("parseCron(%q) returned no error", expr)
+		}
+	}
+}
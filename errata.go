@@ -0,0 +1,380 @@
+package gosatellite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const errataPath = katelloBasePath + "/errata"
+const jobInvocationsPath = basePath + "/job_invocations"
+
+// Errata types
+const (
+	ErratumTypeBugfix      = "bugfix"
+	ErratumTypeEnhancement = "enhancement"
+	ErratumTypeSecurity    = "security"
+)
+
+// Remote execution feature used to install errata on hosts
+const errataInstallFeature = "katello_errata_install"
+
+// Erratum defines model for an Erratum.
+type Erratum struct {
+	Bugs                 *[]erratumBug `json:"bugs"`
+	Comparison           *[]int        `json:"comparison"`
+	CVEs                 *[]erratumCVE `json:"cves"`
+	Description          *string       `json:"description"`
+	ErrataID             *string       `json:"errata_id"`
+	HostsApplicableCount *int          `json:"hosts_applicable_count"`
+	HostsAvailableCount  *int          `json:"hosts_available_count"`
+	ID                   *int          `json:"id"`
+	Installable          *bool         `json:"installable"`
+	Issued               *string       `json:"issued"`
+	ModuleStreams        *[]struct {
+		Name     *string   `json:"name"`
+		Stream   *string   `json:"stream"`
+		Version  *string   `json:"version"`
+		Context  *string   `json:"context"`
+		Arch     *string   `json:"arch"`
+		ID       *int      `json:"id"`
+		Packages *[]string `json:"packages"`
+	} `json:"module_streams"`
+	Name            *string   `json:"name"`
+	Packages        *[]string `json:"packages"`
+	PulpID          *string   `json:"pulp_id"`
+	RebootSuggested *bool     `json:"reboot_suggested"`
+	Severity        *string   `json:"severity"`
+	Solution        *string   `json:"solution"`
+	Summary         *string   `json:"summary"`
+	Title           *string   `json:"title"`
+	Type            *string   `json:"type"`
+	Updated         *string   `json:"updated"`
+	UUID            *string   `json:"uuid"`
+}
+
+type erratumBug struct {
+	BugID *string `json:"bug_id"`
+	Href  *string `json:"href"`
+}
+
+type erratumCVE struct {
+	CVEID *string `json:"cve_id"`
+	Href  *string `json:"href"`
+}
+
+// ErrataList defines model for a list of errata.
+type ErrataList struct {
+	searchResults
+	Results *[]Erratum `json:"results"`
+}
+
+// ErrataListOptions specifies the optional parameters to various List methods that
+// support pagination.
+type ErrataListOptions struct {
+	KatelloListOptions
+
+	// ID of an organization to list errata from
+	OrganizationID int `url:"organization_id,omitempty"`
+
+	// ID of a repository to list errata from
+	RepositoryID int `url:"repository_id,omitempty"`
+
+	// ID of a content view version to list errata from
+	ContentViewVersionID int `url:"content_view_version_id,omitempty"`
+
+	// ID of a content view filter to list errata from
+	ContentViewFilterID int `url:"content_view_filter_id,omitempty"`
+
+	// ID of a lifecycle environment to list errata from
+	EnvironmentID int `url:"environment_id,omitempty"`
+
+	// ID of a host to list errata from
+	HostID int `url:"host_id,omitempty"`
+
+	// If true, only return errata that are applicable to one or more hosts
+	ErrataRestrictApplicable bool `url:"errata_restrict_applicable,omitempty"`
+
+	// If true, only return errata that are installable on one or more hosts
+	ErrataRestrictInstallable bool `url:"errata_restrict_installable,omitempty"`
+
+	// Only return errata of a type, one of the ErratumType constants
+	Type string `url:"-"`
+
+	// Only return errata of a severity, e.g. Critical, Important, Moderate or Low
+	Severity string `url:"-"`
+
+	// Only return errata fixing a CVE, e.g. CVE-2021-3156
+	CVE string `url:"-"`
+
+	// Only return errata issued after a date, formatted as YYYY-MM-DD
+	IssuedAfter string `url:"-"`
+
+	// Only return errata issued before a date, formatted as YYYY-MM-DD
+	IssuedBefore string `url:"-"`
+}
+
+// withSearch returns a copy of the options with the type, severity, CVE and issued
+// filters added to the search query.
+func (o *ErrataListOptions) withSearch() *ErrataListOptions {
+	if o == nil {
+		return nil
+	}
+
+	opt := *o
+
+	var terms []string
+	if opt.Search != "" {
+		terms = append(terms, "("+opt.Search+")")
+	}
+	if opt.Type != "" {
+		terms = append(terms, fmt.Sprintf("type = %q", opt.Type))
+	}
+	if opt.Severity != "" {
+		terms = append(terms, fmt.Sprintf("severity = %q", opt.Severity))
+	}
+	if opt.CVE != "" {
+		terms = append(terms, fmt.Sprintf("cve = %q", opt.CVE))
+	}
+	if opt.IssuedAfter != "" {
+		terms = append(terms, fmt.Sprintf("issued > %q", opt.IssuedAfter))
+	}
+	if opt.IssuedBefore != "" {
+		terms = append(terms, fmt.Sprintf("issued < %q", opt.IssuedBefore))
+	}
+
+	opt.Search = strings.Join(terms, " and ")
+
+	return &opt
+}
+
+// ErrataCompareOptions specifies the optional parameters to Errata.Compare.
+type ErrataCompareOptions struct {
+	KatelloListOptions
+
+	// IDs of repositories to restrict the comparison to
+	RepositoryIDs []int `url:"repository_ids,omitempty,brackets"`
+}
+
+// HostErrataListOptions specifies the optional parameters to the methods listing the
+// errata of a host.
+type HostErrataListOptions struct {
+	KatelloListOptions
+
+	// Calculate applicability against a content view instead of the one of the host
+	ContentViewID int `url:"content_view_id,omitempty"`
+
+	// Calculate applicability against a lifecycle environment instead of the one of the host
+	EnvironmentID int `url:"environment_id,omitempty"`
+
+	// Only return errata of a severity, e.g. Critical, Important, Moderate or Low
+	Severity string `url:"severity,omitempty"`
+
+	// Only return errata of a type, one of the ErratumType constants
+	Type string `url:"type,omitempty"`
+}
+
+// ErrataApply defines model for installing errata on hosts through a remote execution job.
+// Target hosts are given either by their IDs or by a host search query.
+type ErrataApply struct {
+	ErrataIDs []string
+	HostIDs   []int
+	Search    string
+}
+
+// JobInvocation defines model for a remote execution Job Invocation.
+type JobInvocation struct {
+	Description *string `json:"description"`
+	Failed      *int    `json:"failed"`
+	ID          *int    `json:"id"`
+	JobCategory *string `json:"job_category"`
+	Pending     *int    `json:"pending"`
+	StartAt     *string `json:"start_at"`
+	Status      *int    `json:"status"`
+	StatusLabel *string `json:"status_label"`
+	Succeeded   *int    `json:"succeeded"`
+	TargetingID *int    `json:"targeting_id"`
+	Task        *struct {
+		ID    *string `json:"id"`
+		State *string `json:"state"`
+	} `json:"task"`
+	Total *int `json:"total"`
+}
+
+// Errata is an interface for interacting with
+// Red Hat Satellite Errata
+type Errata interface {
+	Apply(ctx context.Context, errataApply ErrataApply) (*JobInvocation, *http.Response, error)
+	Compare(ctx context.Context, cvvIDs []int, opt *ErrataCompareOptions) (*ErrataList, *http.Response, error)
+	Get(ctx context.Context, erratumID string) (*Erratum, *http.Response, error)
+	List(ctx context.Context, opt *ErrataListOptions) (*ErrataList, *http.Response, error)
+	ListApplicableByHostID(ctx context.Context, hostID int, opt *HostErrataListOptions) (*ErrataList, *http.Response, error)
+	ListInstallableByHostID(ctx context.Context, hostID int, opt *HostErrataListOptions) (*ErrataList, *http.Response, error)
+}
+
+// ErrataOp handles communication with the Errata related methods of the
+// Red Hat Satellite REST API
+type ErrataOp struct {
+	client *Client
+}
+
+// Apply installs errata on hosts by starting a remote execution job. The job can be
+// followed with the task of the returned job invocation.
+func (s *ErrataOp) Apply(ctx context.Context, errataApply ErrataApply) (*JobInvocation, *http.Response, error) {
+	path := jobInvocationsPath
+
+	if len(errataApply.ErrataIDs) < 1 {
+		return nil, nil, NewArgError("errataApply.ErrataIDs", "cannot be empty")
+	}
+
+	search := errataApply.Search
+	if len(errataApply.HostIDs) > 0 {
+		if search != "" {
+			return nil, nil, NewArgError("errataApply.HostIDs and errataApply.Search", "cannot both be set")
+		}
+
+		ids := make([]string, len(errataApply.HostIDs))
+		for i, id := range errataApply.HostIDs {
+			ids[i] = fmt.Sprint(id)
+		}
+		search = fmt.Sprintf("id ^ (%s)", strings.Join(ids, ","))
+	} else if search == "" {
+		return nil, nil, NewArgError("Both errataApply.HostIDs and errataApply.Search", "cannot be empty")
+	}
+
+	var body struct {
+		JobInvocation struct {
+			Feature       string            `json:"feature"`
+			Inputs        map[string]string `json:"inputs"`
+			SearchQuery   string            `json:"search_query"`
+			TargetingType string            `json:"targeting_type"`
+		} `json:"job_invocation"`
+	}
+
+	body.JobInvocation.Feature = errataInstallFeature
+	body.JobInvocation.Inputs = map[string]string{"errata": strings.Join(errataApply.ErrataIDs, ",")}
+	body.JobInvocation.SearchQuery = search
+	body.JobInvocation.TargetingType = "static_query"
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	job := new(JobInvocation)
+	resp, err := s.client.Do(ctx, req, job)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return job, resp, err
+}
+
+// Compare the errata of content view versions. The Comparison field of each returned
+// erratum lists the versions it belongs to.
+func (s *ErrataOp) Compare(ctx context.Context, cvvIDs []int, opt *ErrataCompareOptions) (*ErrataList, *http.Response, error) {
+	if len(cvvIDs) < 1 {
+		return nil, nil, NewArgError("cvvIDs", "cannot be empty")
+	}
+
+	var query struct {
+		ErrataCompareOptions
+		ContentViewVersionIDs []int `url:"content_view_version_ids,brackets"`
+	}
+
+	if opt != nil {
+		query.ErrataCompareOptions = *opt
+	}
+	query.ContentViewVersionIDs = cvvIDs
+
+	path := errataPath + "/compare"
+	path, err := addOptions(path, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.list(ctx, path)
+}
+
+// Get a single erratum by its ID or its errata ID, e.g. RHSA-2021:0221
+func (s *ErrataOp) Get(ctx context.Context, erratumID string) (*Erratum, *http.Response, error) {
+	if erratumID == "" {
+		return nil, nil, NewArgError("erratumID", "cannot be empty")
+	}
+
+	path := fmt.Sprintf("%s/%s", errataPath, erratumID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	erratum := new(Erratum)
+	resp, err := s.client.Do(ctx, req, erratum)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return erratum, resp, err
+}
+
+// Performs a list request given a path.
+func (s *ErrataOp) list(ctx context.Context, path string) (*ErrataList, *http.Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(ErrataList)
+	resp, err := s.client.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, err
+}
+
+// List all errata or a filtered list of errata
+func (s *ErrataOp) List(ctx context.Context, opt *ErrataListOptions) (*ErrataList, *http.Response, error) {
+	path := errataPath
+	path, err := addOptions(path, opt.withSearch())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.list(ctx, path)
+}
+
+// ListApplicableByHostID lists the errata applicable to a host, including those not yet
+// available in its content view and lifecycle environment
+func (s *ErrataOp) ListApplicableByHostID(ctx context.Context, hostID int, opt *HostErrataListOptions) (*ErrataList, *http.Response, error) {
+	return s.listByHostID(ctx, hostID, opt, true)
+}
+
+// Performs a list request of the errata of a host.
+func (s *ErrataOp) listByHostID(ctx context.Context, hostID int, opt *HostErrataListOptions, includeApplicable bool) (*ErrataList, *http.Response, error) {
+	var query struct {
+		HostErrataListOptions
+		IncludeApplicable bool `url:"include_applicable"`
+	}
+
+	if opt != nil {
+		query.HostErrataListOptions = *opt
+	}
+	query.IncludeApplicable = includeApplicable
+
+	path := fmt.Sprintf("%s/%d/errata", hostsPath, hostID)
+	path, err := addOptions(path, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.list(ctx, path)
+}
+
+// ListInstallableByHostID lists the errata that can be installed on a host from its
+// content view and lifecycle environment
+func (s *ErrataOp) ListInstallableByHostID(ctx context.Context, hostID int, opt *HostErrataListOptions) (*ErrataList, *http.Response, error) {
+	return s.listByHostID(ctx, hostID, opt, false)
+}
@@ -1,12 +1,29 @@
 package gosatellite
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
 
-// ArgError is an error that represents an error with an input to godo. It
+// Sentinel errors matched by errors.Is against the errors returned for the corresponding
+// HTTP status codes of the API.
+var (
+	ErrUnauthorized = errors.New("gosatellite: unauthorized")
+	ErrForbidden    = errors.New("gosatellite: forbidden")
+	ErrNotFound     = errors.New("gosatellite: not found")
+	ErrConflict     = errors.New("gosatellite: conflict")
+)
+
+// ArgError is an error that represents an error with an input to gosatellite. It
 // identifies the argument and the cause (if possible).
 type ArgError struct {
-	arg    string
-	reason string
+	// Name of the argument, e.g. productCreate.Name
+	Arg string
+
+	// Why the argument is invalid
+	Reason string
 }
 
 var _ error = &ArgError{}
@@ -14,11 +31,92 @@ var _ error = &ArgError{}
 // NewArgError creates an InputError.
 func NewArgError(arg, reason string) *ArgError {
 	return &ArgError{
-		arg:    arg,
-		reason: reason,
+		Arg:    arg,
+		Reason: reason,
 	}
 }
 
 func (e *ArgError) Error() string {
-	return fmt.Sprintf("%s is invalid because %s", e.arg, e.reason)
+	return fmt.Sprintf("%s is invalid because %s", e.Arg, e.Reason)
+}
+
+// ValidationError is returned when the API rejects a request with 422 Unprocessable
+// Entity. It wraps the ErrorResponse of the request.
+type ValidationError struct {
+	*ErrorResponse
+
+	// Messages for each invalid field of the request, keyed by the field name
+	Fields map[string][]string
+
+	// Full messages describing the validation failures
+	Messages []string
+}
+
+var _ error = &ValidationError{}
+
+func newValidationError(r *ErrorResponse) *ValidationError {
+	v := &ValidationError{ErrorResponse: r, Fields: make(map[string][]string)}
+
+	if r.ErrorStruct == nil {
+		return v
+	}
+
+	if r.ErrorStruct.FullMessages != nil {
+		v.Messages = append(v.Messages, *r.ErrorStruct.FullMessages...)
+	}
+
+	if r.ErrorStruct.Errors != nil {
+		var fields map[string][]string
+		if err := json.Unmarshal(*r.ErrorStruct.Errors, &fields); err == nil {
+			for field, messages := range fields {
+				v.Fields[field] = messages
+			}
+		}
+	}
+
+	return v
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Fields) == 0 || len(e.Messages) > 0 {
+		return e.ErrorResponse.Error()
+	}
+
+	return fmt.Sprintf("%s (%s)", e.ErrorResponse.Error(), strings.Join(fieldMessages(e.Fields), "; "))
+}
+
+// Unwrap returns the ErrorResponse of the request
+func (e *ValidationError) Unwrap() error {
+	return e.ErrorResponse
+}
+
+// TaskError is returned when a Foreman task finished with an error or a warning.
+type TaskError struct {
+	Task *Task
+}
+
+var _ error = &TaskError{}
+
+func (e *TaskError) Error() string {
+	if e.Task == nil {
+		return "task finished with an error"
+	}
+
+	id, label, result := "", "", ""
+	if e.Task.ID != nil {
+		id = *e.Task.ID
+	}
+	if e.Task.Label != nil {
+		label = *e.Task.Label
+	}
+	if e.Task.Result != nil {
+		result = *e.Task.Result
+	}
+
+	msg := fmt.Sprintf("task %s (%s) finished with result %s", id, label, result)
+	if e.Task.Humanized != nil && e.Task.Humanized.Errors != nil && len(*e.Task.Humanized.Errors) > 0 {
+		msg += ": " + strings.Join(*e.Task.Humanized.Errors, "|")
+	}
+
+	return msg
 }
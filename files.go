@@ -0,0 +1,100 @@
+package gosatellite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const filesPath = katelloBasePath + "/files"
+
+// File defines model for a File unit of a file repository.
+type File struct {
+	Checksum     *string            `json:"checksum"`
+	ID           *int               `json:"id"`
+	Name         *string            `json:"name"`
+	Path         *string            `json:"path"`
+	PulpID       *string            `json:"pulp_id"`
+	Repositories *[]shortRepository `json:"repositories"`
+	UUID         *string            `json:"uuid"`
+}
+
+// FilesList defines model for a list of files.
+type FilesList struct {
+	searchResults
+	Results *[]File `json:"results"`
+}
+
+// FilesListOptions specifies the optional parameters to various List methods that
+// support pagination.
+type FilesListOptions struct {
+	KatelloListOptions
+
+	// ID of an organization to list files from
+	OrganizationID int `url:"organization_id,omitempty"`
+
+	// ID of a repository to list files from
+	RepositoryID int `url:"repository_id,omitempty"`
+
+	// ID of a content view version to list files from
+	ContentViewVersionID int `url:"content_view_version_id,omitempty"`
+
+	// ID of a content view filter to list files from
+	ContentViewFilterID int `url:"content_view_filter_id,omitempty"`
+
+	// ID of a lifecycle environment to list files from
+	EnvironmentID int `url:"environment_id,omitempty"`
+}
+
+// Files is an interface for interacting with
+// Red Hat Satellite Files
+type Files interface {
+	Get(ctx context.Context, fileID int) (*File, *http.Response, error)
+	List(ctx context.Context, opt *FilesListOptions) (*FilesList, *http.Response, error)
+}
+
+// FilesOp handles communication with the File related methods of the
+// Red Hat Satellite REST API
+type FilesOp struct {
+	client *Client
+}
+
+// Get a single file by its ID
+func (s *FilesOp) Get(ctx context.Context, fileID int) (*File, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", filesPath, fileID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file := new(File)
+	resp, err := s.client.Do(ctx, req, file)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return file, resp, err
+}
+
+// List all files or a filtered list of files. Files are not installed on hosts, so there is no host filter.
+func (s *FilesOp) List(ctx context.Context, opt *FilesListOptions) (*FilesList, *http.Response, error) {
+	path := filesPath
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(FilesList)
+	resp, err := s.client.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, err
+}
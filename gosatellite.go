@@ -3,27 +3,28 @@ package gosatellite
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"mime/multipart"
 	"net/http"
 	"net/url"
 	"reflect"
+	stdsort "sort"
 	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
 
 const (
-	libraryVersion  = "0.1.0"
-	defaultBaseURL  = "https://satellite.example.com"
-	userAgent       = "gosatellite/" + libraryVersion
-	mediaType       = "application/json"
-	basePath        = "/api"
-	katelloBasePath = "/katello/api"
+	libraryVersion       = "0.1.0"
+	defaultBaseURL       = "https://satellite.example.com"
+	userAgent            = "gosatellite/" + libraryVersion
+	mediaType            = "application/json"
+	basePath             = "/api"
+	katelloBasePath      = "/katello/api"
+	foremanTasksBasePath = "/foreman_tasks/api"
 )
 
 // Config defines the configuration needed to connect to the
@@ -33,6 +34,37 @@ type Config struct {
 	Password      string
 	SatelliteHost string
 	SSLVerify     bool
+
+	// Optional authenticator for the requests. When nil requests are authenticated with
+	// Username and Password using basic authentication.
+	Authenticator Authenticator
+
+	// Path to a PEM encoded CA bundle used to verify the server, such as the katello-server-ca
+	CACertFile string
+
+	// PEM encoded CA certificates used to verify the server, in addition to CACertFile
+	CACert []byte
+
+	// Paths to a PEM encoded client certificate and key used for mutual TLS authentication
+	ClientCertFile string
+	ClientKeyFile  string
+
+	// PEM encoded client certificate and key, used when ClientCertFile and ClientKeyFile are not set
+	ClientCert []byte
+	ClientKey  []byte
+
+	// Time limit for requests made by the client, including reading the response body.
+	// Zero means no timeout.
+	Timeout time.Duration
+
+	// URL of the proxy to use. When empty the proxy is taken from the environment.
+	ProxyURL string
+
+	// Optional HTTP client to use instead of the one built from the settings above
+	HTTPClient *http.Client
+
+	// Optional policy for retrying requests that failed with a transient error
+	RetryPolicy *RetryPolicy
 }
 
 // Client is the API client for Red Hat Satellite
@@ -52,25 +84,43 @@ type Client struct {
 	// Services used for communicating with the API
 	ActivationKeys        ActivationKeys
 	AuthSourceLDAPs       AuthSourceLDAPs
+	ContentViewComponents ContentViewComponents
+	ContentViewFilters    ContentViewFilters
 	ContentViews          ContentViews
+	ContentViewVersions   ContentViewVersions
+	Errata                Errata
 	ExternalUserGroups    ExternalUserGroups
+	Files                 Files
 	Filters               Filters
 	HostCollections       HostCollections
+	Hosts                 Hosts
 	LifecycleEnvironments LifecycleEnvironments
 	Locations             Locations
 	Manifests             Manifests
+	ModuleStreams         ModuleStreams
 	Organizations         Organizations
+	PackageGroups         PackageGroups
+	Packages              Packages
 	Permissions           Permissions
 	Products              Products
 	Repositories          Repositories
+	RepositorySets        RepositorySets
 	Roles                 Roles
+	Subscriptions         Subscriptions
+	SyncPlans             SyncPlans
+	Tasks                 Tasks
 	UserGroups            UserGroups
+	Users                 Users
 
-	// Optional function called after every successful request made to the Red Hat Satellite APIs
+	// Optional function called after every successful request made to the Red Hat Satellite APIs.
+	// When a retry policy is configured it is called once for every attempt.
 	onRequestCompleted RequestCompletionCallback
 
 	// Optional extra HTTP headers to set on every request to the API.
 	headers map[string]string
+
+	// Organization and location context added to every request of a scoped client.
+	scope *requestScope
 }
 
 // RequestCompletionCallback defines the type of the request callback function
@@ -112,11 +162,25 @@ type ErrorResponse struct {
 	// HTTP response that caused this error
 	Response *http.Response
 
-	// Error message
-	ErrorStruct *struct {
-		FullMessages *[]string `json:"full_messages"`
-		Message      *string   `json:"message"`
-	} `json:"error"`
+	// Error message, normalized from the different error formats returned by Foreman, Katello
+	// and Candlepin. It is nil when the response body could not be parsed.
+	ErrorStruct *ErrorMessages `json:"error"`
+
+	// Raw response body, kept for debugging responses that are not JSON such as the HTML
+	// error pages of a proxy
+	Body []byte `json:"-"`
+}
+
+// ErrorMessages defines model for the messages of an API error.
+type ErrorMessages struct {
+	// Messages for each invalid field as a JSON object of field names to lists of messages
+	Errors *json.RawMessage `json:"errors"`
+
+	// Full messages describing the error
+	FullMessages *[]string `json:"full_messages"`
+
+	// Summary of the error
+	Message *string `json:"message"`
 }
 
 func addOptions(s string, opt interface{}) (string, error) {
@@ -154,30 +218,50 @@ func NewClient(config *Config) (*Client, error) {
 		return nil, err
 	}
 
-	if !config.SSLVerify {
-		http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
 	}
 
-	c := &Client{client: http.DefaultClient, BaseURL: baseURL, UserAgent: userAgent, Config: config}
+	c := &Client{client: httpClient, BaseURL: baseURL, UserAgent: userAgent, Config: config}
+	c.setServices()
+
+	c.headers = make(map[string]string)
+
+	return c, nil
+}
+
+// setServices binds the services of the client to it
+func (c *Client) setServices() {
 	c.ActivationKeys = &ActivationKeysOp{client: c}
 	c.AuthSourceLDAPs = &AuthSourceLDAPsOp{client: c}
+	c.ContentViewComponents = &ContentViewComponentsOp{client: c}
+	c.ContentViewFilters = &ContentViewFiltersOp{client: c}
 	c.ContentViews = &ContentViewsOp{client: c}
+	c.ContentViewVersions = &ContentViewVersionsOp{client: c}
+	c.Errata = &ErrataOp{client: c}
 	c.ExternalUserGroups = &ExternalUserGroupsOp{client: c}
+	c.Files = &FilesOp{client: c}
 	c.Filters = &FiltersOp{client: c}
 	c.HostCollections = &HostCollectionsOp{client: c}
+	c.Hosts = &HostsOp{client: c}
 	c.LifecycleEnvironments = &LifecycleEnvironmentsOp{client: c}
 	c.Locations = &LocationsOp{client: c}
 	c.Manifests = &ManifestsOp{client: c}
+	c.ModuleStreams = &ModuleStreamsOp{client: c}
 	c.Organizations = &OrganizationsOp{client: c}
+	c.PackageGroups = &PackageGroupsOp{client: c}
+	c.Packages = &PackagesOp{client: c}
 	c.Permissions = &PermissionsOp{client: c}
 	c.Products = &ProductsOp{client: c}
 	c.Repositories = &RepositoriesOp{client: c}
+	c.RepositorySets = &RepositorySetsOp{client: c}
 	c.Roles = &RolesOp{client: c}
+	c.Subscriptions = &SubscriptionsOp{client: c}
+	c.SyncPlans = &SyncPlansOp{client: c}
+	c.Tasks = &TasksOp{client: c}
 	c.UserGroups = &UserGroupsOp{client: c}
-
-	c.headers = make(map[string]string)
-
-	return c, nil
+	c.Users = &UsersOp{client: c}
 }
 
 // NewRequest creates an API request. A relative URL can be provided in urlStr, which will be resolved to the
@@ -189,6 +273,10 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body int
 		return nil, err
 	}
 
+	if err := c.scope.apply(u); err != nil {
+		return nil, err
+	}
+
 	var req *http.Request
 	switch method {
 	case http.MethodGet, http.MethodHead, http.MethodOptions:
@@ -206,6 +294,10 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body int
 			}
 		}
 
+		if err := c.scope.checkBody(buf.Bytes()); err != nil {
+			return nil, err
+		}
+
 		req, err = http.NewRequest(method, u.String(), buf)
 		if err != nil {
 			return nil, err
@@ -220,7 +312,9 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body int
 	req.Header.Set("Accept", mediaType)
 	req.Header.Set("User-Agent", c.UserAgent)
 
-	req.SetBasicAuth(c.Config.Username, c.Config.Password)
+	if err := c.authenticate(req); err != nil {
+		return nil, err
+	}
 
 	return req, nil
 }
@@ -229,37 +323,25 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body int
 // BaseURL of the Client. Relative URLS should always be specified without a preceding slash. The content in form is
 // added to the request as form data
 func (c *Client) NewManifestUploadRequest(ctx context.Context, method, urlStr string, manifest []byte, manifestFilename string) (*http.Request, error) {
-	rel, err := url.Parse(urlStr)
-	if err != nil {
-		return nil, err
-	}
-
-	u := c.BaseURL.ResolveReference(rel)
-
-	var buf bytes.Buffer
-	w := multipart.NewWriter(&buf)
-	var fw io.Writer
-	if fw, err = w.CreateFormFile("content", manifestFilename); err != nil {
-		return nil, err
-	}
-	if _, err = fw.Write(manifest); err != nil {
-		return nil, err
-	}
-	w.Close()
+	return c.NewMultipartRequest(ctx, method, urlStr, nil, MultipartFile{
+		Field:    "content",
+		Filename: manifestFilename,
+		Content:  bytes.NewReader(manifest),
+	})
+}
 
-	req, err := http.NewRequest(method, u.String(), &buf)
-	if err != nil {
-		return nil, err
+// authenticator returns the authenticator of the client, defaulting to basic authentication
+func (c *Client) authenticator() Authenticator {
+	if c.Config.Authenticator != nil {
+		return c.Config.Authenticator
 	}
 
-	req.SetBasicAuth(c.Config.Username, c.Config.Password)
-
-	req.Header.Add("Content-Type", w.FormDataContentType())
-	req.Header.Add("Multipart", "true")
-	req.Header.Add("Accept", mediaType)
-	req.Header.Add("User-Agent", c.UserAgent)
+	return &BasicAuth{Username: c.Config.Username, Password: c.Config.Password}
+}
 
-	return req, nil
+// authenticate adds the credentials of the client to a request
+func (c *Client) authenticate(req *http.Request) error {
+	return c.authenticator().Authenticate(req)
 }
 
 // OnRequestCompleted sets the Red Hat Satellite API request completion callback
@@ -271,13 +353,10 @@ func (c *Client) OnRequestCompleted(rc RequestCompletionCallback) {
 // pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
 // the raw response will be written to v, without attempting to decode it.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
-	resp, err := DoRequestWithClient(ctx, c.client, req)
+	resp, err := c.doWithRetry(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	if c.onRequestCompleted != nil {
-		c.onRequestCompleted(req, resp)
-	}
 
 	defer func() {
 		if rerr := resp.Body.Close(); err == nil {
@@ -301,12 +380,45 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*htt
 			if err != nil {
 				return nil, err
 			}
+			bindClient(reflect.ValueOf(v), c)
 		}
 	}
 
 	return resp, err
 }
 
+// clientBinder is implemented by the models which keep a reference to the client that
+// returned them, such as Task
+type clientBinder interface {
+	bindClient(c *Client)
+}
+
+var clientBinderType = reflect.TypeOf((*clientBinder)(nil)).Elem()
+
+// bindClient binds c to every model implementing clientBinder found in v, including the
+// ones nested in lists and other models
+func bindClient(v reflect.Value, c *Client) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			bindClient(v.Elem(), c)
+		}
+	case reflect.Struct:
+		if v.CanAddr() && v.Addr().Type().Implements(clientBinderType) && v.Addr().CanInterface() {
+			v.Addr().Interface().(clientBinder).bindClient(c)
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Type().Field(i); f.PkgPath == "" || f.Anonymous {
+				bindClient(v.Field(i), c)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			bindClient(v.Index(i), c)
+		}
+	}
+}
+
 // DoRequest submits an HTTP request.
 func DoRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
 	return DoRequestWithClient(ctx, http.DefaultClient, req)
@@ -323,21 +435,60 @@ func DoRequestWithClient(
 
 func (r *ErrorResponse) Error() string {
 	allMessages := []string{}
+	seen := make(map[string]bool)
+	add := func(msg string) {
+		if msg != "" && !seen[msg] {
+			seen[msg] = true
+			allMessages = append(allMessages, msg)
+		}
+	}
+
 	if r.ErrorStruct != nil {
 		if r.ErrorStruct.FullMessages != nil {
-			allMessages = append(allMessages, *r.ErrorStruct.FullMessages...)
+			for _, msg := range *r.ErrorStruct.FullMessages {
+				add(msg)
+			}
 		}
 		if r.ErrorStruct.Message != nil {
-			allMessages = append(allMessages, *r.ErrorStruct.Message)
+			add(*r.ErrorStruct.Message)
 		}
 	}
+
+	if len(allMessages) == 0 {
+		allMessages = append(allMessages, http.StatusText(r.Response.StatusCode))
+	}
+
 	return fmt.Sprintf("%v %v: %d %v",
 		r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, strings.Join(allMessages, "|"))
 }
 
+// Is reports whether the error matches one of the sentinel errors for the status code of
+// the response, so that errors.Is(err, ErrNotFound) holds for a 404.
+func (r *ErrorResponse) Is(target error) bool {
+	if r.Response == nil {
+		return false
+	}
+
+	switch r.Response.StatusCode {
+	case http.StatusUnauthorized:
+		return target == ErrUnauthorized
+	case http.StatusForbidden:
+		return target == ErrForbidden
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusConflict:
+		return target == ErrConflict
+	}
+
+	return false
+}
+
 // CheckResponse checks the API response for errors, and returns them if present. A response is considered an
-// error if it has a status code outside the 200 range. API error responses are expected to have either no response
-// body, or a JSON response body that maps to ErrorResponse. Any other response body will be silently ignored.
+// error if it has a status code outside the 200 range. The JSON error bodies of Foreman ({"error": {...}}),
+// Katello and Candlepin ({"displayMessage": ..., "errors": [...]}) and Rails validations ({"errors": {...}})
+// are normalized into the ErrorStruct of an ErrorResponse. Any other response body is only kept in its Body.
+// A 422 status code is returned as a *ValidationError, and errors for the 401, 403, 404 and 409 status codes
+// match the corresponding sentinel errors with errors.Is.
 func CheckResponse(r *http.Response) error {
 	if c := r.StatusCode; c >= 200 && c <= 299 {
 		return nil
@@ -346,15 +497,87 @@ func CheckResponse(r *http.Response) error {
 	errorResponse := &ErrorResponse{Response: r}
 	data, err := ioutil.ReadAll(r.Body)
 	if err == nil && len(data) > 0 {
-		err := json.Unmarshal(data, errorResponse)
-		if err != nil {
-			errorResponse.ErrorStruct.FullMessages = &[]string{string(data)}
-		}
+		errorResponse.Body = data
+		errorResponse.ErrorStruct = parseErrorBody(data)
+	}
+
+	if r.StatusCode == http.StatusUnprocessableEntity {
+		return newValidationError(errorResponse)
 	}
 
 	return errorResponse
 }
 
+// parseErrorBody normalizes the known formats of API error bodies. It returns nil if the
+// body is not one of them.
+func parseErrorBody(data []byte) *ErrorMessages {
+	var body struct {
+		Error          *json.RawMessage `json:"error"`
+		DisplayMessage *string          `json:"displayMessage"`
+		Errors         *json.RawMessage `json:"errors"`
+	}
+
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil
+	}
+
+	messages := new(ErrorMessages)
+
+	if body.Error != nil {
+		// Foreman nests the messages in an error object, some endpoints only give a string
+		var message string
+		if err := json.Unmarshal(*body.Error, &message); err == nil {
+			messages.Message = &message
+		} else if err := json.Unmarshal(*body.Error, messages); err != nil {
+			return nil
+		}
+	}
+
+	if body.DisplayMessage != nil && messages.Message == nil {
+		messages.Message = body.DisplayMessage
+	}
+
+	if body.Errors != nil && messages.Errors == nil {
+		var list []string
+		var fields map[string][]string
+		if err := json.Unmarshal(*body.Errors, &list); err == nil {
+			if messages.FullMessages == nil {
+				messages.FullMessages = &list
+			}
+		} else if err := json.Unmarshal(*body.Errors, &fields); err == nil {
+			messages.Errors = body.Errors
+			if messages.FullMessages == nil {
+				full := fieldMessages(fields)
+				messages.FullMessages = &full
+			}
+		}
+	}
+
+	if messages.Message == nil && messages.FullMessages == nil && messages.Errors == nil {
+		return nil
+	}
+
+	return messages
+}
+
+// fieldMessages formats messages for each field as "field message", sorted by field
+func fieldMessages(fields map[string][]string) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	stdsort.Strings(names)
+
+	full := []string{}
+	for _, name := range names {
+		for _, msg := range fields[name] {
+			full = append(full, name+" "+msg)
+		}
+	}
+
+	return full
+}
+
 // String is a helper routine that allocates a new string value
 // to store v and returns a pointer to it.
 func String(v string) *string {
@@ -0,0 +1,495 @@
+package gosatellite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const hostsPath = basePath + "/hosts"
+
+// Host status types that can be requested with Hosts.Status
+const (
+	HostStatusTypeBuild         = "build"
+	HostStatusTypeConfiguration = "configuration"
+	HostStatusTypeGlobal        = "global"
+)
+
+// Host defines model for a Host.
+type Host struct {
+	AllParameters               *[]HostParameter       `json:"all_parameters"`
+	Architecture                *genericShortRef       `json:"-"`
+	BuildStatus                 *int                   `json:"build_status"`
+	BuildStatusLabel            *string                `json:"build_status_label"`
+	Certname                    *string                `json:"certname"`
+	Comment                     *string                `json:"comment"`
+	ConfigurationStatus         *int                   `json:"configuration_status"`
+	ConfigurationStatusLabel    *string                `json:"configuration_status_label"`
+	ContentFacetAttributes      *HostContentFacet      `json:"content_facet_attributes"`
+	CreatedAt                   *string                `json:"created_at"`
+	Domain                      *genericShortRef       `json:"-"`
+	Enabled                     *bool                  `json:"enabled"`
+	ErrataStatus                *int                   `json:"errata_status"`
+	ErrataStatusLabel           *string                `json:"errata_status_label"`
+	GlobalStatus                *int                   `json:"global_status"`
+	GlobalStatusLabel           *string                `json:"global_status_label"`
+	HostCollections             *[]genericShortRef     `json:"host_collections"`
+	Hostgroup                   *genericReference      `json:"-"`
+	ID                          *int                   `json:"id"`
+	InstalledAt                 *string                `json:"installed_at"`
+	IP                          *string                `json:"ip"`
+	IP6                         *string                `json:"ip6"`
+	LastCompile                 *string                `json:"last_compile"`
+	LastReport                  *string                `json:"last_report"`
+	Location                    *genericReference      `json:"-"`
+	MAC                         *string                `json:"mac"`
+	Managed                     *bool                  `json:"managed"`
+	Name                        *string                `json:"name"`
+	OperatingSystem             *genericShortRef       `json:"-"`
+	Organization                *genericReference      `json:"-"`
+	Parameters                  *[]HostParameter       `json:"parameters"`
+	SubscriptionFacetAttributes *HostSubscriptionFacet `json:"subscription_facet_attributes"`
+	SubscriptionStatus          *int                   `json:"subscription_status"`
+	SubscriptionStatusLabel     *string                `json:"subscription_status_label"`
+	UpdatedAt                   *string                `json:"updated_at"`
+	UptimeSeconds               *int                   `json:"uptime_seconds"`
+}
+
+// UnmarshalJSON decodes a host and gathers the flat *_id, *_name and *_title attributes
+// returned by the API into references.
+func (h *Host) UnmarshalJSON(data []byte) error {
+	type host Host
+	var flat struct {
+		host
+		ArchitectureID      *int    `json:"architecture_id"`
+		ArchitectureName    *string `json:"architecture_name"`
+		DomainID            *int    `json:"domain_id"`
+		DomainName          *string `json:"domain_name"`
+		HostgroupID         *int    `json:"hostgroup_id"`
+		HostgroupName       *string `json:"hostgroup_name"`
+		HostgroupTitle      *string `json:"hostgroup_title"`
+		LocationID          *int    `json:"location_id"`
+		LocationName        *string `json:"location_name"`
+		OperatingSystemID   *int    `json:"operatingsystem_id"`
+		OperatingSystemName *string `json:"operatingsystem_name"`
+		OrganizationID      *int    `json:"organization_id"`
+		OrganizationName    *string `json:"organization_name"`
+	}
+
+	if err := json.Unmarshal(data, &flat); err != nil {
+		return err
+	}
+
+	*h = Host(flat.host)
+
+	if flat.ArchitectureID != nil {
+		h.Architecture = &genericShortRef{ID: flat.ArchitectureID, Name: flat.ArchitectureName}
+	}
+	if flat.DomainID != nil {
+		h.Domain = &genericShortRef{ID: flat.DomainID, Name: flat.DomainName}
+	}
+	if flat.HostgroupID != nil {
+		h.Hostgroup = &genericReference{ID: flat.HostgroupID, Name: flat.HostgroupName, Title: flat.HostgroupTitle}
+	}
+	if flat.LocationID != nil {
+		h.Location = &genericReference{ID: flat.LocationID, Name: flat.LocationName}
+	}
+	if flat.OperatingSystemID != nil {
+		h.OperatingSystem = &genericShortRef{ID: flat.OperatingSystemID, Name: flat.OperatingSystemName}
+	}
+	if flat.OrganizationID != nil {
+		h.Organization = &genericReference{ID: flat.OrganizationID, Name: flat.OrganizationName}
+	}
+
+	return nil
+}
+
+// HostContentFacet defines model for the content attributes Katello adds to a host.
+type HostContentFacet struct {
+	ApplicableModuleStreamCount *int             `json:"applicable_module_stream_count"`
+	ApplicablePackageCount      *int             `json:"applicable_package_count"`
+	ContentSource               *genericShortRef `json:"content_source"`
+	ContentView                 *genericShortRef `json:"content_view"`
+	ContentViewDefault          *bool            `json:"content_view_default"`
+	ContentViewVersion          *string          `json:"content_view_version"`
+	ContentViewVersionID        *int             `json:"content_view_version_id"`
+	ErrataCounts                *leErrataCounts  `json:"errata_counts"`
+	ID                          *int             `json:"id"`
+	KickstartRepository         *genericShortRef `json:"kickstart_repository"`
+	LifecycleEnvironment        *genericShortRef `json:"lifecycle_environment"`
+	LifecycleEnvironmentLibrary *bool            `json:"lifecycle_environment_library"`
+	UpgradableModuleStreamCount *int             `json:"upgradable_module_stream_count"`
+	UpgradablePackageCount      *int             `json:"upgradable_package_count"`
+	UUID                        *string          `json:"uuid"`
+}
+
+// HostSubscriptionFacet defines model for the subscription attributes Katello adds to a host.
+type HostSubscriptionFacet struct {
+	ActivationKeys    *[]genericShortRef      `json:"activation_keys"`
+	Autoheal          *bool                   `json:"autoheal"`
+	ComplianceReasons *[]string               `json:"compliance_reasons"`
+	Hypervisor        *bool                   `json:"hypervisor"`
+	ID                *int                    `json:"id"`
+	InstalledProducts *[]hostInstalledProduct `json:"installed_products"`
+	LastCheckin       *string                 `json:"last_checkin"`
+	PurposeAddons     *[]string               `json:"purpose_addons"`
+	PurposeRole       *string                 `json:"purpose_role"`
+	PurposeUsage      *string                 `json:"purpose_usage"`
+	RegisteredAt      *string                 `json:"registered_at"`
+	RegisteredThrough *string                 `json:"registered_through"`
+	ReleaseVersion    *string                 `json:"release_version"`
+	ServiceLevel      *string                 `json:"service_level"`
+	User              *genericUser            `json:"user"`
+	UUID              *string                 `json:"uuid"`
+	VirtualGuests     *[]genericShortRef      `json:"virtual_guests"`
+	VirtualHost       *genericShortRef        `json:"virtual_host"`
+}
+
+type hostInstalledProduct struct {
+	Arch        *string `json:"arch"`
+	ProductID   *string `json:"productId"`
+	ProductName *string `json:"productName"`
+	Version     *string `json:"version"`
+}
+
+// HostParameter defines model for a parameter of a host. Value holds the JSON value of the
+// parameter, whose type is given by ParameterType, e.g. a JSON boolean for a boolean parameter.
+type HostParameter struct {
+	CreatedAt     *string          `json:"created_at"`
+	HiddenValue   *bool            `json:"hidden_value?"`
+	ID            *int             `json:"id"`
+	Name          *string          `json:"name"`
+	ParameterType *string          `json:"parameter_type"`
+	Priority      *int             `json:"priority"`
+	UpdatedAt     *string          `json:"updated_at"`
+	Value         *json.RawMessage `json:"value"`
+}
+
+// HostParameterCreate defines model for creating or updating a parameter of a host.
+type HostParameterCreate struct {
+	Parameter struct {
+		Name          *string `json:"name,omitempty"`
+		Value         *string `json:"value,omitempty"`
+		ParameterType *string `json:"parameter_type,omitempty"`
+		HiddenValue   *bool   `json:"hidden_value,omitempty"`
+	} `json:"parameter"`
+}
+
+// HostStatus defines model for a status of a host.
+type HostStatus struct {
+	Status      *int    `json:"status"`
+	StatusLabel *string `json:"status_label"`
+}
+
+// HostsList defines model for a list of hosts.
+type HostsList struct {
+	searchResults
+	Results *[]Host `json:"results"`
+}
+
+// HostParametersList defines model for a list of host parameters.
+type HostParametersList struct {
+	searchResults
+	Results *[]HostParameter `json:"results"`
+}
+
+// HostFactsList defines model for the facts of a host, keyed by the host name and then by
+// the fact name.
+type HostFactsList struct {
+	searchResults
+	Results *map[string]map[string]string `json:"results"`
+}
+
+// Facts returns the facts of the list as a single map of fact names to values
+func (l *HostFactsList) Facts() map[string]string {
+	facts := make(map[string]string)
+	if l == nil || l.Results == nil {
+		return facts
+	}
+
+	for _, hostFacts := range *l.Results {
+		for name, value := range hostFacts {
+			facts[name] = value
+		}
+	}
+
+	return facts
+}
+
+// HostsListOptions specifies the optional parameters to various List methods that
+// support pagination.
+type HostsListOptions struct {
+	ListOptions
+
+	// Scope by host group
+	HostgroupID int `url:"hostgroup_id,omitempty"`
+
+	// Scope by locations
+	LocationID int `url:"location_id,omitempty"`
+
+	// Scope by organizations
+	OrganizationID int `url:"organization_id,omitempty"`
+
+	// Only list ID and name of hosts
+	Thin bool `url:"thin,omitempty"`
+
+	// Array of extra information types to include, e.g. parameters or all_parameters
+	Include []string `url:"include,omitempty,brackets"`
+}
+
+// HostUpdate defines model for updating a host.
+type HostUpdate struct {
+	Host struct {
+		Name                        *string                      `json:"name,omitempty"`
+		Comment                     *string                      `json:"comment,omitempty"`
+		Enabled                     *bool                        `json:"enabled,omitempty"`
+		Managed                     *bool                        `json:"managed,omitempty"`
+		Build                       *bool                        `json:"build,omitempty"`
+		HostgroupID                 *int                         `json:"hostgroup_id,omitempty"`
+		LocationID                  *int                         `json:"location_id,omitempty"`
+		OrganizationID              *int                         `json:"organization_id,omitempty"`
+		OwnerID                     *int                         `json:"owner_id,omitempty"`
+		OwnerType                   *string                      `json:"owner_type,omitempty"`
+		ContentFacetAttributes      *HostContentFacetUpdate      `json:"content_facet_attributes,omitempty"`
+		SubscriptionFacetAttributes *HostSubscriptionFacetUpdate `json:"subscription_facet_attributes,omitempty"`
+	} `json:"host"`
+}
+
+// HostContentFacetUpdate defines model for updating the content attributes of a host.
+type HostContentFacetUpdate struct {
+	ContentViewID          *int `json:"content_view_id,omitempty"`
+	LifecycleEnvironmentID *int `json:"lifecycle_environment_id,omitempty"`
+	ContentSourceID        *int `json:"content_source_id,omitempty"`
+	KickstartRepositoryID  *int `json:"kickstart_repository_id,omitempty"`
+}
+
+// HostSubscriptionFacetUpdate defines model for updating the subscription attributes of a host.
+type HostSubscriptionFacetUpdate struct {
+	Autoheal       *bool     `json:"autoheal,omitempty"`
+	PurposeAddons  *[]string `json:"purpose_addons,omitempty"`
+	PurposeRole    *string   `json:"purpose_role,omitempty"`
+	PurposeUsage   *string   `json:"purpose_usage,omitempty"`
+	ReleaseVersion *string   `json:"release_version,omitempty"`
+	ServiceLevel   *string   `json:"service_level,omitempty"`
+}
+
+// Hosts is an interface for interacting with
+// Red Hat Satellite Hosts
+type Hosts interface {
+	CreateParameter(ctx context.Context, hostID int, paramCreate HostParameterCreate) (*HostParameter, *http.Response, error)
+	Delete(ctx context.Context, hostID int) (*http.Response, error)
+	DeleteParameter(ctx context.Context, hostID int, parameterID int) (*http.Response, error)
+	Get(ctx context.Context, hostID int) (*Host, *http.Response, error)
+	List(ctx context.Context, opt *HostsListOptions) (*HostsList, *http.Response, error)
+	ListFacts(ctx context.Context, hostID int, opt *ListOptions) (*HostFactsList, *http.Response, error)
+	ListParameters(ctx context.Context, hostID int, opt *ListOptions) (*HostParametersList, *http.Response, error)
+	Status(ctx context.Context, hostID int, statusType string) (*HostStatus, *http.Response, error)
+	Update(ctx context.Context, hostID int, hostUpdate HostUpdate) (*Host, *http.Response, error)
+	UpdateParameter(ctx context.Context, hostID int, parameterID int, paramUpdate HostParameterCreate) (*HostParameter, *http.Response, error)
+}
+
+// HostsOp handles communication with the Host related methods of the
+// Red Hat Satellite REST API
+type HostsOp struct {
+	client *Client
+}
+
+// CreateParameter creates a new parameter for a host
+func (s *HostsOp) CreateParameter(ctx context.Context, hostID int, paramCreate HostParameterCreate) (*HostParameter, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/parameters", hostsPath, hostID)
+
+	if paramCreate.Parameter.Name == nil || *paramCreate.Parameter.Name == "" {
+		return nil, nil, NewArgError("paramCreate.Parameter.Name", "cannot be empty")
+	}
+
+	return s.parameterRequest(ctx, http.MethodPost, path, paramCreate)
+}
+
+// Delete a host by its ID
+func (s *HostsOp) Delete(ctx context.Context, hostID int) (*http.Response, error) {
+	path := fmt.Sprintf("%s/%d", hostsPath, hostID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, err
+}
+
+// DeleteParameter deletes a parameter of a host by its ID
+func (s *HostsOp) DeleteParameter(ctx context.Context, hostID int, parameterID int) (*http.Response, error) {
+	path := fmt.Sprintf("%s/%d/parameters/%d", hostsPath, hostID, parameterID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, err
+}
+
+// Get a single host by its ID
+func (s *HostsOp) Get(ctx context.Context, hostID int) (*Host, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", hostsPath, hostID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	host := new(Host)
+	resp, err := s.client.Do(ctx, req, host)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return host, resp, err
+}
+
+// List all hosts or a filtered list of hosts
+func (s *HostsOp) List(ctx context.Context, opt *HostsListOptions) (*HostsList, *http.Response, error) {
+	path := hostsPath
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(HostsList)
+	resp, err := s.client.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, err
+}
+
+// ListFacts lists the facts reported by a host
+func (s *HostsOp) ListFacts(ctx context.Context, hostID int, opt *ListOptions) (*HostFactsList, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/facts", hostsPath, hostID)
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(HostFactsList)
+	resp, err := s.client.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, err
+}
+
+// ListParameters lists the parameters of a host
+func (s *HostsOp) ListParameters(ctx context.Context, hostID int, opt *ListOptions) (*HostParametersList, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/parameters", hostsPath, hostID)
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(HostParametersList)
+	resp, err := s.client.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, err
+}
+
+// Performs a request given a path that returns a host parameter.
+func (s *HostsOp) parameterRequest(ctx context.Context, method, path string, body interface{}) (*HostParameter, *http.Response, error) {
+	req, err := s.client.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	param := new(HostParameter)
+	resp, err := s.client.Do(ctx, req, param)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return param, resp, err
+}
+
+// Status gets a status of a host, one of the HostStatusType constants. An empty
+// statusType returns the global status.
+func (s *HostsOp) Status(ctx context.Context, hostID int, statusType string) (*HostStatus, *http.Response, error) {
+	if statusType == "" {
+		statusType = HostStatusTypeGlobal
+	}
+
+	path := fmt.Sprintf("%s/%d/status/%s", hostsPath, hostID, statusType)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status := new(HostStatus)
+	resp, err := s.client.Do(ctx, req, status)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return status, resp, err
+}
+
+// Update a host
+func (s *HostsOp) Update(ctx context.Context, hostID int, hostUpdate HostUpdate) (*Host, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", hostsPath, hostID)
+
+	if hostUpdate.Host.Name != nil && *hostUpdate.Host.Name == "" {
+		return nil, nil, NewArgError("hostUpdate.Host.Name", "cannot be an empty string")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPut, path, hostUpdate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	host := new(Host)
+	resp, err := s.client.Do(ctx, req, host)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return host, resp, err
+}
+
+// UpdateParameter updates a parameter of a host
+func (s *HostsOp) UpdateParameter(ctx context.Context, hostID int, parameterID int, paramUpdate HostParameterCreate) (*HostParameter, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/parameters/%d", hostsPath, hostID, parameterID)
+
+	if paramUpdate.Parameter.Name != nil && *paramUpdate.Parameter.Name == "" {
+		return nil, nil, NewArgError("paramUpdate.Parameter.Name", "cannot be an empty string")
+	}
+
+	return s.parameterRequest(ctx, http.MethodPut, path, paramUpdate)
+}
@@ -0,0 +1,53 @@
+package gosatellite
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestHostsTypedParameters(t *testing.T) {
+	params := `[
+		{"id":1,"name":"enabled","parameter_type":"boolean","value":true},
+		{"id":2,"name":"ports","parameter_type":"array","value":[22,443]},
+		{"id":3,"name":"owner","parameter_type":"string","value":"admin"}
+	]`
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/hosts/1":
+			w.Write([]byte(`{"id":1,"parameters":` + params + `,"all_parameters":` + params + `}`))
+		case "/api/hosts/1/parameters":
+			w.Write([]byte(`{"results":` + params + `}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}, nil)
+	ctx := context.Background()
+
+	host, _, err := client.Hosts.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	list, _, err := client.Hosts.ListParameters(ctx, 1, nil)
+	if err != nil {
+		t.Fatalf("ListParameters returned error: %v", err)
+	}
+
+	want := []interface{}{true, []interface{}{22.0, 443.0}, "admin"}
+	for name, got := range map[string]*[]HostParameter{"host": host.Parameters, "list": list.Results} {
+		if got == nil || len(*got) != len(want) {
+			t.Fatalf("%s: got parameters %v, want %d", name, got, len(want))
+		}
+		for i, param := range *got {
+			var value interface{}
+			if err := json.Unmarshal(*param.Value, &value); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(value, want[i]) {
+				t.Errorf("%s: got value %v for %s, want %v", name, value, *param.Name, want[i])
+			}
+		}
+	}
+}
@@ -77,11 +77,124 @@ type LifecycleEnvironmentsList struct {
 	Results *[]LifecycleEnvironment `json:"results"`
 }
 
+// LifecycleEnvironmentCreate defines model for creating a Lifecycle Environment. PriorID is the
+// environment the new one follows in its path, Library to start a new path.
+type LifecycleEnvironmentCreate struct {
+	OrganizationID              *int    `json:"organization_id"`
+	Name                        *string `json:"name"`
+	Label                       *string `json:"label,omitempty"`
+	Description                 *string `json:"description,omitempty"`
+	PriorID                     *int    `json:"prior_id"`
+	RegistryNamePattern         *string `json:"registry_name_pattern,omitempty"`
+	RegistryUnauthenticatedPull *bool   `json:"registry_unauthenticated_pull,omitempty"`
+}
+
+// LifecycleEnvironmentUpdate defines model for updating a Lifecycle Environment.
+type LifecycleEnvironmentUpdate struct {
+	Name                        *string `json:"new_name,omitempty"`
+	Description                 *string `json:"description,omitempty"`
+	RegistryNamePattern         *string `json:"registry_name_pattern,omitempty"`
+	RegistryUnauthenticatedPull *bool   `json:"registry_unauthenticated_pull,omitempty"`
+}
+
+// LifecycleEnvironmentPath is a chain of Lifecycle Environments ordered from Library to the
+// last environment content is promoted to.
+type LifecycleEnvironmentPath []LifecycleEnvironment
+
+// Next returns the environment following envID in the path, or nil if envID is the last
+// environment or is not in the path
+func (p LifecycleEnvironmentPath) Next(envID int) *LifecycleEnvironment {
+	for i := range p {
+		if p[i].ID != nil && *p[i].ID == envID && i+1 < len(p) {
+			return &p[i+1]
+		}
+	}
+
+	return nil
+}
+
+// LifecycleEnvironmentPaths are all the environment paths of an organization. Each path
+// starts with the Library environment of the organization.
+type LifecycleEnvironmentPaths []LifecycleEnvironmentPath
+
+// Next returns the environments following envID in any path. Only Library can be followed
+// by more than one environment.
+func (p LifecycleEnvironmentPaths) Next(envID int) []LifecycleEnvironment {
+	next := []LifecycleEnvironment{}
+	seen := make(map[int]bool)
+
+	for _, path := range p {
+		env := path.Next(envID)
+		if env != nil && env.ID != nil && !seen[*env.ID] {
+			seen[*env.ID] = true
+			next = append(next, *env)
+		}
+	}
+
+	return next
+}
+
+// orderLifecycleEnvironmentPath orders the environments of a path by following their prior
+// and successor references from the environment without a prior in the path. The original
+// order is kept when the references do not form a single chain.
+func orderLifecycleEnvironmentPath(envs []LifecycleEnvironment) LifecycleEnvironmentPath {
+	byID := make(map[int]LifecycleEnvironment)
+	byPriorID := make(map[int]LifecycleEnvironment)
+	for _, env := range envs {
+		if env.ID == nil {
+			return envs
+		}
+		byID[*env.ID] = env
+		if env.Prior != nil && env.Prior.ID != nil {
+			byPriorID[*env.Prior.ID] = env
+		}
+	}
+
+	var first *LifecycleEnvironment
+	for i, env := range envs {
+		if env.Prior == nil || env.Prior.ID == nil {
+			first = &envs[i]
+			break
+		}
+		if _, ok := byID[*env.Prior.ID]; !ok {
+			first = &envs[i]
+			break
+		}
+	}
+	if first == nil {
+		return envs
+	}
+
+	path := LifecycleEnvironmentPath{*first}
+	for current := *first; len(path) < len(envs); {
+		next, ok := LifecycleEnvironment{}, false
+		if current.Successor != nil && current.Successor.ID != nil {
+			next, ok = byID[*current.Successor.ID]
+		}
+		if !ok {
+			next, ok = byPriorID[*current.ID]
+		}
+		if !ok {
+			return envs
+		}
+
+		path = append(path, next)
+		current = next
+	}
+
+	return path
+}
+
 // LifecycleEnvironments is an interface for interacting with
 // Red Hat Satellite Lifecycle Environments
 type LifecycleEnvironments interface {
+	Create(ctx context.Context, leCreate LifecycleEnvironmentCreate) (*LifecycleEnvironment, *http.Response, error)
+	Delete(ctx context.Context, leID int) (*http.Response, error)
+	Get(ctx context.Context, leID int) (*LifecycleEnvironment, *http.Response, error)
 	List(ctx context.Context, opt *LifecycleEnvironmentsListOptions) (*LifecycleEnvironmentsList, *http.Response, error)
 	ListByOrganizationID(ctx context.Context, orgID int, opt *LifecycleEnvironmentsListOptions) (*LifecycleEnvironmentsList, *http.Response, error)
+	Paths(ctx context.Context, orgID int) (LifecycleEnvironmentPaths, *http.Response, error)
+	Update(ctx context.Context, leID int, leUpdate LifecycleEnvironmentUpdate) (*LifecycleEnvironment, *http.Response, error)
 }
 
 // LifecycleEnvironmentsOp handles communication with the Lifecycle Environments related methods of the
@@ -90,6 +203,70 @@ type LifecycleEnvironmentsOp struct {
 	client *Client
 }
 
+// Create a new Lifecycle Environment
+func (s *LifecycleEnvironmentsOp) Create(ctx context.Context, leCreate LifecycleEnvironmentCreate) (*LifecycleEnvironment, *http.Response, error) {
+	path := katelloEnvironmentsPath
+
+	if leCreate.OrganizationID == nil {
+		return nil, nil, NewArgError("leCreate.OrganizationID", "cannot be empty")
+	}
+
+	if leCreate.Name == nil || *leCreate.Name == "" {
+		return nil, nil, NewArgError("leCreate.Name", "cannot be empty")
+	}
+
+	if leCreate.PriorID == nil {
+		return nil, nil, NewArgError("leCreate.PriorID", "cannot be empty")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, leCreate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	le := new(LifecycleEnvironment)
+	resp, err := s.client.Do(ctx, req, le)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return le, resp, err
+}
+
+// Delete a Lifecycle Environment by its ID. Only the last environment of a path can be deleted.
+func (s *LifecycleEnvironmentsOp) Delete(ctx context.Context, leID int) (*http.Response, error) {
+	path := fmt.Sprintf("%s/%d", katelloEnvironmentsPath, leID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, err
+}
+
+// Get a single Lifecycle Environment by its ID
+func (s *LifecycleEnvironmentsOp) Get(ctx context.Context, leID int) (*LifecycleEnvironment, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", katelloEnvironmentsPath, leID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	le := new(LifecycleEnvironment)
+	resp, err := s.client.Do(ctx, req, le)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return le, resp, err
+}
+
 // Performs a list request given a path.
 func (s *LifecycleEnvironmentsOp) list(ctx context.Context, path string) (*LifecycleEnvironmentsList, *http.Response, error) {
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
@@ -127,3 +304,59 @@ func (s *LifecycleEnvironmentsOp) ListByOrganizationID(ctx context.Context, orgI
 
 	return s.list(ctx, path)
 }
+
+// Paths lists the environment paths of an organization, each ordered from Library to its
+// last environment
+func (s *LifecycleEnvironmentsOp) Paths(ctx context.Context, orgID int) (LifecycleEnvironmentPaths, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/environments/paths", katelloOrganizationsPath, orgID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var list struct {
+		searchResults
+		Results *[]struct {
+			Environments *[]LifecycleEnvironment `json:"environments"`
+		} `json:"results"`
+	}
+
+	resp, err := s.client.Do(ctx, req, &list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	paths := LifecycleEnvironmentPaths{}
+	if list.Results != nil {
+		for _, result := range *list.Results {
+			if result.Environments != nil {
+				paths = append(paths, orderLifecycleEnvironmentPath(*result.Environments))
+			}
+		}
+	}
+
+	return paths, resp, err
+}
+
+// Update a Lifecycle Environment
+func (s *LifecycleEnvironmentsOp) Update(ctx context.Context, leID int, leUpdate LifecycleEnvironmentUpdate) (*LifecycleEnvironment, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", katelloEnvironmentsPath, leID)
+
+	if leUpdate.Name != nil && *leUpdate.Name == "" {
+		return nil, nil, NewArgError("leUpdate.Name", "cannot be an empty string")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPut, path, leUpdate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	le := new(LifecycleEnvironment)
+	resp, err := s.client.Do(ctx, req, le)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return le, resp, err
+}
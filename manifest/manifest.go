@@ -0,0 +1,316 @@
+// Package manifest reads Red Hat subscription manifests offline, so that their content can
+// be inspected and checked against an organization before they are uploaded to Satellite.
+package manifest
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// consumerExportName is the name of the archive nested in a manifest which holds the export
+const consumerExportName = "consumer_export.zip"
+
+// Manifest is the content of a subscription manifest
+type Manifest struct {
+	Meta         *Meta
+	Consumer     *Consumer
+	Entitlements []Entitlement
+}
+
+// Meta defines model for the meta.json file of a manifest
+type Meta struct {
+	CDNLabel      *string `json:"cdnLabel"`
+	Created       *Time   `json:"created"`
+	PrincipalName *string `json:"principalName"`
+	Version       *string `json:"version"`
+	WebAppPrefix  *string `json:"webAppPrefix"`
+}
+
+// Consumer defines model for the distributor the manifest was exported from, i.e. the
+// upstream consumer of the organization importing the manifest
+type Consumer struct {
+	ContentAccessMode *string        `json:"contentAccessMode"`
+	Name              *string        `json:"name"`
+	Owner             *ConsumerOwner `json:"owner"`
+	Type              *ConsumerType  `json:"type"`
+	URLAPI            *string        `json:"urlApi"`
+	URLWeb            *string        `json:"urlWeb"`
+	UUID              *string        `json:"uuid"`
+}
+
+// ConsumerOwner defines model for the upstream account owning the consumer of a manifest
+type ConsumerOwner struct {
+	DisplayName *string `json:"displayName"`
+	ID          *string `json:"id"`
+	Key         *string `json:"key"`
+}
+
+// ConsumerType defines model for the type of the consumer of a manifest
+type ConsumerType struct {
+	ID       *string `json:"id"`
+	Label    *string `json:"label"`
+	Manifest *bool   `json:"manifest"`
+}
+
+// Entitlement defines model for an entitlement of a manifest, i.e. a quantity of a
+// subscription pool attached to the consumer
+type Entitlement struct {
+	EndDate   *Time   `json:"endDate"`
+	ID        *string `json:"id"`
+	Pool      *Pool   `json:"pool"`
+	Quantity  *int    `json:"quantity"`
+	StartDate *Time   `json:"startDate"`
+}
+
+// Pool defines model for the upstream subscription pool of an entitlement
+type Pool struct {
+	AccountNumber    *string        `json:"accountNumber"`
+	ContractNumber   *string        `json:"contractNumber"`
+	EndDate          *Time          `json:"endDate"`
+	ID               *string        `json:"id"`
+	OrderNumber      *string        `json:"orderNumber"`
+	ProductID        *string        `json:"productId"`
+	ProductName      *string        `json:"productName"`
+	ProvidedProducts *[]PoolProduct `json:"providedProducts"`
+	Quantity         *int           `json:"quantity"`
+	StartDate        *Time          `json:"startDate"`
+	SubscriptionID   *string        `json:"subscriptionId"`
+}
+
+// PoolProduct defines model for a product provided by a subscription pool
+type PoolProduct struct {
+	ProductID   *string `json:"productId"`
+	ProductName *string `json:"productName"`
+}
+
+// Time is a date of a manifest. Candlepin writes dates with a numeric zone offset and
+// optional milliseconds, which time.Time does not unmarshal.
+type Time struct {
+	time.Time
+}
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.000-0700",
+	"2006-01-02T15:04:05-0700",
+}
+
+// UnmarshalJSON parses a Candlepin date
+func (t *Time) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	for _, layout := range timeLayouts {
+		parsed, err := time.Parse(layout, s)
+		if err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+
+	return fmt.Errorf("manifest: cannot parse date %q", s)
+}
+
+// Open reads the manifest file at path
+func Open(path string) (*Manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return Read(f, info.Size())
+}
+
+// Parse reads a manifest held in memory
+func Parse(data []byte) (*Manifest, error) {
+	return Read(bytes.NewReader(data), int64(len(data)))
+}
+
+// Read reads a manifest of the given size from r. The manifest is a zip archive holding
+// the signed consumer_export.zip, which contains the exported files.
+func Read(r io.ReaderAt, size int64) (*Manifest, error) {
+	outer, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("manifest: %s", err)
+	}
+
+	var export *zip.File
+	for _, f := range outer.File {
+		if path.Base(f.Name) == consumerExportName {
+			export = f
+			break
+		}
+	}
+	if export == nil {
+		return nil, fmt.Errorf("manifest: %s not found, the file is not a subscription manifest", consumerExportName)
+	}
+
+	data, err := readZipFile(export)
+	if err != nil {
+		return nil, err
+	}
+
+	inner, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("manifest: %s: %s", consumerExportName, err)
+	}
+
+	return readExport(inner)
+}
+
+// readExport parses the files of consumer_export.zip. They are stored under a top level
+// export directory.
+func readExport(export *zip.Reader) (*Manifest, error) {
+	m := new(Manifest)
+
+	for _, f := range export.File {
+		name := f.Name
+		if i := strings.Index(name, "/"); i >= 0 {
+			name = name[i+1:]
+		}
+
+		var v interface{}
+		switch {
+		case name == "meta.json":
+			m.Meta = new(Meta)
+			v = m.Meta
+		case name == "consumer.json":
+			m.Consumer = new(Consumer)
+			v = m.Consumer
+		case path.Dir(name) == "entitlements" && path.Ext(name) == ".json":
+			m.Entitlements = append(m.Entitlements, Entitlement{})
+			v = &m.Entitlements[len(m.Entitlements)-1]
+		default:
+			continue
+		}
+
+		data, err := readZipFile(f)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(data, v); err != nil {
+			return nil, fmt.Errorf("manifest: %s: %s", f.Name, err)
+		}
+	}
+
+	if m.Meta == nil {
+		return nil, fmt.Errorf("manifest: meta.json not found in %s", consumerExportName)
+	}
+	if m.Consumer == nil {
+		return nil, fmt.Errorf("manifest: consumer.json not found in %s", consumerExportName)
+	}
+
+	sort.Slice(m.Entitlements, func(i, j int) bool {
+		return stringValue(m.Entitlements[i].ID) < stringValue(m.Entitlements[j].ID)
+	})
+
+	return m, nil
+}
+
+// Pools returns the subscription pools of the entitlements of the manifest, once each and
+// ordered by ID
+func (m *Manifest) Pools() []Pool {
+	seen := make(map[string]bool)
+	pools := []Pool{}
+
+	for _, e := range m.Entitlements {
+		if e.Pool == nil || seen[stringValue(e.Pool.ID)] {
+			continue
+		}
+		seen[stringValue(e.Pool.ID)] = true
+		pools = append(pools, *e.Pool)
+	}
+
+	sort.Slice(pools, func(i, j int) bool {
+		return stringValue(pools[i].ID) < stringValue(pools[j].ID)
+	})
+
+	return pools
+}
+
+// Expires returns the earliest end date of the entitlements of the manifest. It returns
+// false when no entitlement has an end date.
+func (m *Manifest) Expires() (time.Time, bool) {
+	var expires time.Time
+	found := false
+
+	for _, e := range m.Entitlements {
+		end := e.End()
+		if end.IsZero() {
+			continue
+		}
+		if !found || end.Before(expires) {
+			expires = end
+			found = true
+		}
+	}
+
+	return expires, found
+}
+
+// ExpiredAt returns the entitlements of the manifest which have ended at the given time
+func (m *Manifest) ExpiredAt(at time.Time) []Entitlement {
+	expired := []Entitlement{}
+
+	for _, e := range m.Entitlements {
+		if end := e.End(); !end.IsZero() && !at.Before(end) {
+			expired = append(expired, e)
+		}
+	}
+
+	return expired
+}
+
+// End returns the end date of the entitlement, or of its pool when the entitlement does
+// not set one. The zero time is returned when neither is known.
+func (e Entitlement) End() time.Time {
+	if e.EndDate != nil {
+		return e.EndDate.Time
+	}
+	if e.Pool != nil && e.Pool.EndDate != nil {
+		return e.Pool.EndDate.Time
+	}
+
+	return time.Time{}
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("manifest: %s: %s", f.Name, err)
+	}
+	defer rc.Close()
+
+	data, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("manifest: %s: %s", f.Name, err)
+	}
+
+	return data, nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,301 @@
+package manifest
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/umich-vci/gosatellite"
+)
+
+const (
+	testMeta     = `{"version":"4.2.1","created":"2024-01-02T03:04:05.678+0000","principalName":"admin","webAppPrefix":"https://sat.example.com/","cdnLabel":"Red Hat"}`
+	testConsumer = `{"uuid":"consumer-uuid","name":"satellite","contentAccessMode":"org_environment","type":{"id":"1","label":"satellite","manifest":true},"owner":{"id":"1","key":"123456","displayName":"123456"}}`
+)
+
+func testEntitlement(id, poolID, end string) string {
+	return `{"id":"` + id + `","quantity":2,"startDate":"2023-01-01T00:00:00.000+0000","endDate":` + end +
+		`,"pool":{"id":"` + poolID + `","productId":"RH00001","productName":"Red Hat Enterprise Linux","endDate":"2025-06-30T23:59:59-0400"}}`
+}
+
+// buildManifest returns a manifest archive holding a consumer_export.zip with the files
+func buildManifest(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	export := new(bytes.Buffer)
+	inner := zip.NewWriter(export)
+	for name, content := range files {
+		w, err := inner.Create("export/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := inner.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := new(bytes.Buffer)
+	outer := zip.NewWriter(manifest)
+	for name, content := range map[string][]byte{
+		consumerExportName: export.Bytes(),
+		"signature":        []byte("signature"),
+	} {
+		w, err := outer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := outer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return manifest.Bytes()
+}
+
+func testManifest(t *testing.T) *Manifest {
+	t.Helper()
+
+	m, err := Parse(buildManifest(t, map[string]string{
+		"meta.json":                    testMeta,
+		"consumer.json":                testConsumer,
+		"entitlements/b.json":          testEntitlement("b", "pool-1", `"2024-12-31T23:59:59.000+0000"`),
+		"entitlements/a.json":          testEntitlement("a", "pool-2", `"2024-06-30T00:00:00Z"`),
+		"entitlements/c.json":          testEntitlement("c", "pool-1", `null`),
+		"products/69.json":             `{"id":"69"}`,
+		"upstream_consumer/cert.json":  `{}`,
+		"entitlement_certificates/1.p": "certificate",
+	}))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	return m
+}
+
+func TestParse(t *testing.T) {
+	m := testManifest(t)
+
+	if m.Meta.Version == nil || *m.Meta.Version != "4.2.1" {
+		t.Errorf("got version %v, want 4.2.1", m.Meta.Version)
+	}
+	if want := time.Date(2024, 1, 2, 3, 4, 5, 678000000, time.UTC); !m.Meta.Created.Equal(want) {
+		t.Errorf("got created %v, want %v", m.Meta.Created, want)
+	}
+	if m.Consumer.UUID == nil || *m.Consumer.UUID != "consumer-uuid" {
+		t.Errorf("got consumer %v, want consumer-uuid", m.Consumer.UUID)
+	}
+
+	if len(m.Entitlements) != 3 {
+		t.Fatalf("got %d entitlements, want 3", len(m.Entitlements))
+	}
+	for i, id := range []string{"a", "b", "c"} {
+		if got := stringValue(m.Entitlements[i].ID); got != id {
+			t.Errorf("got entitlement %q at %d, want %q", got, i, id)
+		}
+	}
+}
+
+func TestOpen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "manifest.zip")
+	data := buildManifest(t, map[string]string{"meta.json": testMeta, "consumer.json": testConsumer})
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	if len(m.Entitlements) != 0 {
+		t.Errorf("got %d entitlements, want none", len(m.Entitlements))
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	noExport := new(bytes.Buffer)
+	w := zip.NewWriter(noExport)
+	if _, err := w.Create("signature"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string][]byte{
+		"not a zip":           []byte("not a manifest"),
+		"no consumer export":  noExport.Bytes(),
+		"no meta":             buildManifest(t, map[string]string{"consumer.json": testConsumer}),
+		"no consumer":         buildManifest(t, map[string]string{"meta.json": testMeta}),
+		"invalid json":        buildManifest(t, map[string]string{"meta.json": testMeta, "consumer.json": "{"}),
+		"invalid date layout": buildManifest(t, map[string]string{"meta.json": `{"created":"02/01/2024"}`, "consumer.json": testConsumer}),
+	}
+
+	for name, data := range tests {
+		if _, err := Parse(data); err == nil {
+			t.Errorf("%s: Parse returned no error", name)
+		}
+	}
+}
+
+func TestTimeLayouts(t *testing.T) {
+	want := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	for _, s := range []string{
+		"2024-06-30T12:00:00Z",
+		"2024-06-30T12:00:00.000Z",
+		"2024-06-30T08:00:00-04:00",
+		"2024-06-30T12:00:00.000+0000",
+		"2024-06-30T08:00:00.000-0400",
+		"2024-06-30T08:00:00-0400",
+	} {
+		var got Time
+		if err := json.Unmarshal([]byte(`"`+s+`"`), &got); err != nil {
+			t.Errorf("%s: UnmarshalJSON returned error: %v", s, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("%s: got %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestPools(t *testing.T) {
+	pools := testManifest(t).Pools()
+
+	if len(pools) != 2 || stringValue(pools[0].ID) != "pool-1" || stringValue(pools[1].ID) != "pool-2" {
+		t.Errorf("got pools %v, want pool-1 and pool-2 once each", pools)
+	}
+}
+
+func TestExpires(t *testing.T) {
+	m := testManifest(t)
+
+	expires, ok := m.Expires()
+	if want := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC); !ok || !expires.Equal(want) {
+		t.Errorf("Expires = %v, %v, want %v", expires, ok, want)
+	}
+
+	if _, ok := (&Manifest{}).Expires(); ok {
+		t.Error("Expires of a manifest without entitlements returned true")
+	}
+
+	tests := []struct {
+		at   time.Time
+		want []string
+	}{
+		{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), []string{}},
+		{time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), []string{"a"}},
+		{time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), []string{"a", "b"}},
+		{time.Date(2025, 7, 1, 4, 0, 0, 0, time.UTC), []string{"a", "b", "c"}},
+	}
+
+	for _, tt := range tests {
+		got := []string{}
+		for _, e := range m.ExpiredAt(tt.at) {
+			got = append(got, stringValue(e.ID))
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("ExpiredAt(%v) = %v, want %v", tt.at, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("ExpiredAt(%v) = %v, want %v", tt.at, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func testOrganization(t *testing.T, upstreamUUID string) *gosatellite.Organization {
+	t.Helper()
+
+	org := new(gosatellite.Organization)
+	data := `{"id":1}`
+	if upstreamUUID != "" {
+		data = `{"id":1,"owner_details":{"upstreamConsumer":{"uuid":"` + upstreamUUID + `"}}}`
+	}
+	if err := json.Unmarshal([]byte(data), org); err != nil {
+		t.Fatal(err)
+	}
+
+	return org
+}
+
+func TestCheckUpstreamConsumer(t *testing.T) {
+	m := testManifest(t)
+
+	if err := m.CheckUpstreamConsumer(testOrganization(t, "consumer-uuid")); err != nil {
+		t.Errorf("matching consumer returned error: %v", err)
+	}
+	if err := m.CheckUpstreamConsumer(testOrganization(t, "")); err != nil {
+		t.Errorf("organization without a manifest returned error: %v", err)
+	}
+
+	err := m.CheckUpstreamConsumer(testOrganization(t, "other-uuid"))
+	if !errors.Is(err, ErrUpstreamConsumerMismatch) {
+		t.Fatalf("got error %v, want ErrUpstreamConsumerMismatch", err)
+	}
+	var consumerErr *UpstreamConsumerError
+	if !errors.As(err, &consumerErr) || consumerErr.ManifestUUID != "consumer-uuid" || consumerErr.OrganizationUUID != "other-uuid" {
+		t.Errorf("got error %#v, want the UUIDs of both consumers", err)
+	}
+
+	var argErr *gosatellite.ArgError
+	if err := m.CheckUpstreamConsumer(nil); !errors.As(err, &argErr) {
+		t.Errorf("got error %v for a nil organization, want an *ArgError", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	org := testOrganization(t, "consumer-uuid")
+	valid := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	notDistributor := testManifest(t)
+	notDistributor.Consumer.Type.Manifest = gosatellite.Bool(false)
+
+	empty := testManifest(t)
+	empty.Entitlements = nil
+
+	tests := []struct {
+		name string
+		m    *Manifest
+		org  *gosatellite.Organization
+		at   time.Time
+		want error
+	}{
+		{"valid", testManifest(t), org, valid, nil},
+		{"not a distributor", notDistributor, org, valid, ErrNotDistributor},
+		{"no entitlements", empty, org, valid, ErrNoEntitlements},
+		{"expired", testManifest(t), org, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), ErrExpired},
+		{"other consumer", testManifest(t), testOrganization(t, "other-uuid"), valid, ErrUpstreamConsumerMismatch},
+	}
+
+	for _, tt := range tests {
+		err := tt.m.Validate(tt.org, tt.at)
+		if tt.want == nil && err != nil {
+			t.Errorf("%s: Validate returned error: %v", tt.name, err)
+		}
+		if tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("%s: got error %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/umich-vci/gosatellite"
+)
+
+// Sentinel errors matched by errors.Is against the errors returned by Validate
+var (
+	ErrUpstreamConsumerMismatch = errors.New("manifest: upstream consumer mismatch")
+	ErrNotDistributor           = errors.New("manifest: consumer is not a distributor")
+	ErrExpired                  = errors.New("manifest: all entitlements have expired")
+	ErrNoEntitlements           = errors.New("manifest: no entitlements")
+)
+
+// UpstreamConsumerError is returned when a manifest was exported from another upstream
+// consumer than the one of the organization it is checked against. Satellite refuses to
+// import such a manifest unless the current one is deleted first.
+type UpstreamConsumerError struct {
+	// UUID of the consumer the manifest was exported from
+	ManifestUUID string
+
+	// UUID of the upstream consumer of the organization
+	OrganizationUUID string
+}
+
+func (e *UpstreamConsumerError) Error() string {
+	return fmt.Sprintf("manifest: exported from upstream consumer %s but the organization uses %s", e.ManifestUUID, e.OrganizationUUID)
+}
+
+// Is reports whether target is ErrUpstreamConsumerMismatch
+func (e *UpstreamConsumerError) Is(target error) bool {
+	return target == ErrUpstreamConsumerMismatch
+}
+
+// CheckUpstreamConsumer compares the consumer of the manifest with the upstream consumer
+// of the organization, which must be fetched with its owner details, e.g. with
+// Organizations.Get. An organization without an upstream consumer has no manifest yet and
+// accepts any manifest.
+func (m *Manifest) CheckUpstreamConsumer(org *gosatellite.Organization) error {
+	if org == nil {
+		return gosatellite.NewArgError("org", "cannot be nil")
+	}
+
+	if org.OwnerDetails == nil || org.OwnerDetails.UpstreamConsumer == nil || org.OwnerDetails.UpstreamConsumer.UUID == nil {
+		return nil
+	}
+
+	manifestUUID := ""
+	if m.Consumer != nil {
+		manifestUUID = stringValue(m.Consumer.UUID)
+	}
+
+	upstreamUUID := *org.OwnerDetails.UpstreamConsumer.UUID
+	if manifestUUID != upstreamUUID {
+		return &UpstreamConsumerError{
+			ManifestUUID:     manifestUUID,
+			OrganizationUUID: upstreamUUID,
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that the manifest can be uploaded to the organization at the given time:
+// it must be exported from a distributor, have at least one entitlement which has not
+// expired, and match the upstream consumer of the organization.
+func (m *Manifest) Validate(org *gosatellite.Organization, at time.Time) error {
+	if m.Consumer == nil || m.Consumer.Type == nil || m.Consumer.Type.Manifest == nil || !*m.Consumer.Type.Manifest {
+		return ErrNotDistributor
+	}
+
+	if len(m.Entitlements) == 0 {
+		return ErrNoEntitlements
+	}
+
+	if len(m.ExpiredAt(at)) == len(m.Entitlements) {
+		return ErrExpired
+	}
+
+	return m.CheckUpstreamConsumer(org)
+}
@@ -1,9 +1,13 @@
 package gosatellite
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 )
 
 // ManifestHistoryItem defines model for a single manifest history
@@ -14,26 +18,33 @@ type ManifestHistoryItem struct {
 	StatusMessage *string `json:"statusMessage"`
 }
 
-// ManifestUpload defines model for the response from a manifest upload to an organization
-type ManifestUpload struct {
-	ID        *string `json:"id"`
-	Label     *string `json:"label"`
-	Pending   *bool   `json:"pending"`
-	Action    *string `json:"action"`
-	UserName  *string `json:"username"`
-	StartedAt *string `json:"started_at"`
-	EndedAt   *string `json:"ended_at"`
-	State     *string `json:"state"`
-	Result    *string `json:"result"`
+// ManifestUpload defines model for the response from a manifest upload to an organization.
+// It is the task importing the manifest, which can be waited on with Wait.
+type ManifestUpload = Task
+
+// ManifestUploadOptions specifies the optional parameters to Manifests.UploadFile and
+// Manifests.UploadReader.
+type ManifestUploadOptions struct {
+	// Name of the manifest file sent to the server. Defaults to the base name of the
+	// uploaded file, or manifest.zip for a reader.
+	Filename string
+
+	// Repository URL to set on the organization, used to access the Red Hat CDN
+	RepositoryURL *string
+
+	// Optional function called as the manifest is uploaded with the number of bytes sent so far
+	Progress func(sent int64)
 }
 
 // Manifests is an interface for interacting with
 // Red Hat Satellite Subscription Manifests
 type Manifests interface {
-	Delete(ctx context.Context, orgID int) (*http.Response, error)
+	Delete(ctx context.Context, orgID int) (*Task, *http.Response, error)
 	GetHistory(ctx context.Context, orgID int) (*[]ManifestHistoryItem, *http.Response, error)
-	Refresh(ctx context.Context, orgID int) (*http.Response, error)
+	Refresh(ctx context.Context, orgID int) (*Task, *http.Response, error)
 	Upload(ctx context.Context, orgID int, repoURL *string, manifest []byte, manifestFilename string) (*ManifestUpload, *http.Response, error)
+	UploadFile(ctx context.Context, orgID int, manifestPath string, opts *ManifestUploadOptions) (*ManifestUpload, *http.Response, error)
+	UploadReader(ctx context.Context, orgID int, manifest io.Reader, opts *ManifestUploadOptions) (*ManifestUpload, *http.Response, error)
 }
 
 // ManifestsOp handles communication with the Manifest related methods of the
@@ -42,20 +53,22 @@ type ManifestsOp struct {
 	client *Client
 }
 
-// Delete a manifest for an organization by its ID
-func (s *ManifestsOp) Delete(ctx context.Context, orgID int) (*http.Response, error) {
+// Delete a manifest for an organization by its ID. The returned task tracks the removal.
+func (s *ManifestsOp) Delete(ctx context.Context, orgID int) (*Task, *http.Response, error) {
 	path := fmt.Sprintf("%s/%d/subscriptions/delete_manifest", katelloOrganizationsPath, orgID)
 
 	req, err := s.client.NewRequest(ctx, http.MethodPost, path, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	resp, err := s.client.Do(ctx, req, nil)
+
+	task := new(Task)
+	resp, err := s.client.Do(ctx, req, task)
 	if err != nil {
-		return resp, err
+		return nil, resp, err
 	}
 
-	return resp, err
+	return task, resp, err
 }
 
 // GetHistory of a manifest for an organization based on its ID
@@ -76,28 +89,79 @@ func (s *ManifestsOp) GetHistory(ctx context.Context, orgID int) (*[]ManifestHis
 	return hist, resp, err
 }
 
-// Refresh the manifest attached to an organization
-func (s *ManifestsOp) Refresh(ctx context.Context, orgID int) (*http.Response, error) {
+// Refresh the manifest attached to an organization. The returned task tracks the refresh.
+func (s *ManifestsOp) Refresh(ctx context.Context, orgID int) (*Task, *http.Response, error) {
 	path := fmt.Sprintf("%s/%d/subscriptions/refresh_manifest", katelloOrganizationsPath, orgID)
 
 	req, err := s.client.NewRequest(ctx, http.MethodPut, path, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	resp, err := s.client.Do(ctx, req, nil)
+	task := new(Task)
+	resp, err := s.client.Do(ctx, req, task)
 	if err != nil {
-		return resp, err
+		return nil, resp, err
 	}
 
-	return resp, err
+	return task, resp, err
 }
 
-// Upload a manifest to an organization
+// Upload a manifest to an organization. The manifest subpackage can check the manifest
+// against the organization beforehand.
 func (s *ManifestsOp) Upload(ctx context.Context, orgID int, repoURL *string, manifest []byte, manifestFilename string) (*ManifestUpload, *http.Response, error) {
+	opts := &ManifestUploadOptions{Filename: manifestFilename, RepositoryURL: repoURL}
+
+	return s.UploadReader(ctx, orgID, bytes.NewReader(manifest), opts)
+}
+
+// UploadFile uploads a manifest to an organization from a file, streaming its content
+func (s *ManifestsOp) UploadFile(ctx context.Context, orgID int, manifestPath string, opts *ManifestUploadOptions) (*ManifestUpload, *http.Response, error) {
+	f, err := os.Open(manifestPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	fileOpts := ManifestUploadOptions{}
+	if opts != nil {
+		fileOpts = *opts
+	}
+	if fileOpts.Filename == "" {
+		fileOpts.Filename = filepath.Base(manifestPath)
+	}
+
+	return s.UploadReader(ctx, orgID, f, &fileOpts)
+}
+
+// UploadReader uploads a manifest to an organization, streaming its content from a reader
+func (s *ManifestsOp) UploadReader(ctx context.Context, orgID int, manifest io.Reader, opts *ManifestUploadOptions) (*ManifestUpload, *http.Response, error) {
 	path := fmt.Sprintf("%s/%d/subscriptions/upload", katelloOrganizationsPath, orgID)
 
-	req, err := s.client.NewManifestUploadRequest(ctx, http.MethodPost, path, manifest, manifestFilename)
+	if manifest == nil {
+		return nil, nil, NewArgError("manifest", "cannot be empty")
+	}
+
+	if opts == nil {
+		opts = &ManifestUploadOptions{}
+	}
+
+	file := MultipartFile{
+		Field:    "content",
+		Filename: opts.Filename,
+		Content:  manifest,
+		Progress: opts.Progress,
+	}
+	if file.Filename == "" {
+		file.Filename = "manifest.zip"
+	}
+
+	var fields map[string]string
+	if opts.RepositoryURL != nil {
+		fields = map[string]string{"repository_url": *opts.RepositoryURL}
+	}
+
+	req, err := s.client.NewMultipartRequest(ctx, http.MethodPost, path, fields, file)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,118 @@
+package gosatellite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const moduleStreamsPath = katelloBasePath + "/module_streams"
+
+// ModuleStream defines model for a Module Stream.
+type ModuleStream struct {
+	Arch                 *string            `json:"arch"`
+	Artifacts            *[]genericShortRef `json:"artifacts"`
+	Context              *string            `json:"context"`
+	Description          *string            `json:"description"`
+	HostsApplicableCount *int               `json:"hosts_applicable_count"`
+	HostsAvailableCount  *int               `json:"hosts_available_count"`
+	ID                   *int               `json:"id"`
+	ModuleSpec           *string            `json:"module_spec"`
+	Name                 *string            `json:"name"`
+	Profiles             *[]struct {
+		ID   *int               `json:"id"`
+		Name *string            `json:"name"`
+		RPMs *[]genericShortRef `json:"rpms"`
+	} `json:"profiles"`
+	Repositories *[]shortRepository `json:"repositories"`
+	Stream       *string            `json:"stream"`
+	Summary      *string            `json:"summary"`
+	UUID         *string            `json:"uuid"`
+	Version      *string            `json:"version"`
+}
+
+// ModuleStreamsList defines model for a list of module streams.
+type ModuleStreamsList struct {
+	searchResults
+	Results *[]ModuleStream `json:"results"`
+}
+
+// ModuleStreamsListOptions specifies the optional parameters to various List methods that
+// support pagination.
+type ModuleStreamsListOptions struct {
+	KatelloListOptions
+
+	// ID of an organization to list module streams from
+	OrganizationID int `url:"organization_id,omitempty"`
+
+	// ID of a repository to list module streams from
+	RepositoryID int `url:"repository_id,omitempty"`
+
+	// ID of a content view version to list module streams from
+	ContentViewVersionID int `url:"content_view_version_id,omitempty"`
+
+	// ID of a content view filter to list module streams from
+	ContentViewFilterID int `url:"content_view_filter_id,omitempty"`
+
+	// ID of a lifecycle environment to list module streams from
+	EnvironmentID int `url:"environment_id,omitempty"`
+
+	// ID of a host to list applicable module streams for
+	HostID int `url:"host_id,omitempty"`
+
+	// If true, only return a single entry for each name and stream combination
+	NameStreamOnly bool `url:"name_stream_only,omitempty"`
+}
+
+// ModuleStreams is an interface for interacting with
+// Red Hat Satellite Module Streams
+type ModuleStreams interface {
+	Get(ctx context.Context, moduleStreamID int) (*ModuleStream, *http.Response, error)
+	List(ctx context.Context, opt *ModuleStreamsListOptions) (*ModuleStreamsList, *http.Response, error)
+}
+
+// ModuleStreamsOp handles communication with the Module Stream related methods of the
+// Red Hat Satellite REST API
+type ModuleStreamsOp struct {
+	client *Client
+}
+
+// Get a single module stream by its ID
+func (s *ModuleStreamsOp) Get(ctx context.Context, moduleStreamID int) (*ModuleStream, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", moduleStreamsPath, moduleStreamID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stream := new(ModuleStream)
+	resp, err := s.client.Do(ctx, req, stream)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return stream, resp, err
+}
+
+// List all module streams or a filtered list of module streams
+func (s *ModuleStreamsOp) List(ctx context.Context, opt *ModuleStreamsListOptions) (*ModuleStreamsList, *http.Response, error) {
+	path := moduleStreamsPath
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(ModuleStreamsList)
+	resp, err := s.client.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, err
+}
@@ -0,0 +1,184 @@
+package gosatellite
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	stdsort "sort"
+	"sync"
+)
+
+// MultipartFile is the file part of a multipart request built by NewMultipartRequest.
+type MultipartFile struct {
+	// Name of the form field holding the file
+	Field string
+
+	// Name of the file sent to the server
+	Filename string
+
+	// Content of the file. It is streamed while the request is sent and never buffered
+	// as a whole.
+	Content io.Reader
+
+	// Optional function called as the content is sent with the number of bytes sent so far
+	Progress func(sent int64)
+}
+
+// NewMultipartRequest creates an API request with a multipart form body made of the given
+// fields and file. A relative URL can be provided in urlStr, which will be resolved to the
+// BaseURL of the Client. The body is produced through an io.Pipe while the request is sent,
+// so the content of the file is never held in memory. When the content implements io.Seeker
+// the request can be sent again, e.g. when it is retried.
+func (c *Client) NewMultipartRequest(ctx context.Context, method, urlStr string, fields map[string]string, file MultipartFile) (*http.Request, error) {
+	rel, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	u := c.BaseURL.ResolveReference(rel)
+
+	if err := c.scope.apply(u); err != nil {
+		return nil, err
+	}
+
+	if err := c.scope.checkFields(fields); err != nil {
+		return nil, err
+	}
+
+	if file.Field == "" {
+		return nil, NewArgError("file.Field", "cannot be empty")
+	}
+
+	if file.Content == nil {
+		return nil, NewArgError("file.Content", "cannot be empty")
+	}
+
+	boundary := multipart.NewWriter(ioutil.Discard).Boundary()
+	newBody := func() io.ReadCloser {
+		return newPipeBody(func(w io.Writer) error {
+			return writeMultipart(w, boundary, fields, file)
+		})
+	}
+
+	req, err := http.NewRequest(method, u.String(), newBody())
+	if err != nil {
+		return nil, err
+	}
+
+	if seeker, ok := file.Content.(io.Seeker); ok {
+		start, err := seeker.Seek(0, io.SeekCurrent)
+		if err == nil {
+			req.GetBody = func() (io.ReadCloser, error) {
+				if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+					return nil, err
+				}
+				return newBody(), nil
+			}
+		}
+	}
+
+	mw := multipart.NewWriter(ioutil.Discard)
+	if err := mw.SetBoundary(boundary); err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", mw.FormDataContentType())
+	req.Header.Add("Multipart", "true")
+	req.Header.Add("Accept", mediaType)
+	req.Header.Add("User-Agent", c.UserAgent)
+
+	for k, v := range c.headers {
+		req.Header.Add(k, v)
+	}
+
+	if err := c.authenticate(req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// writeMultipart writes the multipart form made of fields and file to w
+func writeMultipart(w io.Writer, boundary string, fields map[string]string, file MultipartFile) error {
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary(boundary); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	stdsort.Strings(names)
+
+	for _, name := range names {
+		if err := mw.WriteField(name, fields[name]); err != nil {
+			return err
+		}
+	}
+
+	part, err := mw.CreateFormFile(file.Field, file.Filename)
+	if err != nil {
+		return err
+	}
+
+	content := file.Content
+	if file.Progress != nil {
+		content = &progressReader{r: content, progress: file.Progress}
+	}
+
+	if _, err := io.Copy(part, content); err != nil {
+		return err
+	}
+
+	return mw.Close()
+}
+
+// pipeBody is a request body produced by a writer function through an io.Pipe. The writer
+// only starts on the first read, so that a body which is never sent does not leak it.
+type pipeBody struct {
+	once  sync.Once
+	pr    *io.PipeReader
+	pw    *io.PipeWriter
+	write func(w io.Writer) error
+}
+
+func newPipeBody(write func(w io.Writer) error) *pipeBody {
+	pr, pw := io.Pipe()
+	return &pipeBody{pr: pr, pw: pw, write: write}
+}
+
+func (b *pipeBody) Read(p []byte) (int, error) {
+	b.once.Do(func() {
+		go func() {
+			b.pw.CloseWithError(b.write(b.pw))
+		}()
+	})
+
+	return b.pr.Read(p)
+}
+
+// Close stops the writer if it is running
+func (b *pipeBody) Close() error {
+	return b.pr.Close()
+}
+
+// progressReader reports the number of bytes read from r
+type progressReader struct {
+	r        io.Reader
+	sent     int64
+	progress func(sent int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.sent += int64(n)
+		p.progress(p.sent)
+	}
+
+	return n, err
+}
@@ -0,0 +1,220 @@
+package gosatellite
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NVRA is the name, epoch, version, release and architecture of an RPM package.
+type NVRA struct {
+	Name    string
+	Epoch   string
+	Version string
+	Release string
+	Arch    string
+}
+
+// ParseNVRA parses a package name formatted as name-[epoch:]version-release.arch, with an
+// optional .rpm suffix. The epoch:name-version-release.arch form is accepted as well.
+func ParseNVRA(s string) (NVRA, error) {
+	var n NVRA
+
+	rest := strings.TrimSuffix(s, ".rpm")
+
+	i := strings.LastIndex(rest, ".")
+	if i < 0 {
+		return NVRA{}, fmt.Errorf("package %q has no architecture", s)
+	}
+	n.Arch, rest = rest[i+1:], rest[:i]
+
+	i = strings.LastIndex(rest, "-")
+	if i < 0 {
+		return NVRA{}, fmt.Errorf("package %q has no release", s)
+	}
+	n.Release, rest = rest[i+1:], rest[:i]
+
+	i = strings.LastIndex(rest, "-")
+	if i < 0 {
+		return NVRA{}, fmt.Errorf("package %q has no version", s)
+	}
+	n.Version, n.Name = rest[i+1:], rest[:i]
+
+	if i = strings.Index(n.Version, ":"); i >= 0 {
+		n.Epoch, n.Version = n.Version[:i], n.Version[i+1:]
+	} else if i = strings.Index(n.Name, ":"); i >= 0 {
+		n.Epoch, n.Name = n.Name[:i], n.Name[i+1:]
+	}
+
+	if n.Name == "" || n.Version == "" || n.Release == "" || n.Arch == "" {
+		return NVRA{}, fmt.Errorf("package %q is not formatted as name-version-release.arch", s)
+	}
+
+	if n.Epoch != "" {
+		if _, err := strconv.Atoi(n.Epoch); err != nil {
+			return NVRA{}, fmt.Errorf("package %q has an invalid epoch %q", s, n.Epoch)
+		}
+	}
+
+	return n, nil
+}
+
+// String formats the package as name-[epoch:]version-release.arch, omitting a zero epoch
+func (n NVRA) String() string {
+	if n.Epoch != "" && n.Epoch != "0" {
+		return fmt.Sprintf("%s-%s:%s-%s.%s", n.Name, n.Epoch, n.Version, n.Release, n.Arch)
+	}
+
+	return fmt.Sprintf("%s-%s-%s.%s", n.Name, n.Version, n.Release, n.Arch)
+}
+
+// Compare the epoch, version and release of two packages the way RPM does. It returns -1 if
+// n is older than o, 1 if it is newer and 0 if they are the same. Names and architectures
+// are not compared.
+func (n NVRA) Compare(o NVRA) int {
+	if c := compareEpoch(n.Epoch, o.Epoch); c != 0 {
+		return c
+	}
+
+	if c := rpmvercmp(n.Version, o.Version); c != 0 {
+		return c
+	}
+
+	return rpmvercmp(n.Release, o.Release)
+}
+
+// compareEpoch compares two epochs, an empty epoch being 0
+func compareEpoch(a, b string) int {
+	ea, errA := strconv.Atoi(a)
+	eb, errB := strconv.Atoi(b)
+	if (errA != nil && a != "") || (errB != nil && b != "") {
+		return rpmvercmp(a, b)
+	}
+
+	switch {
+	case ea < eb:
+		return -1
+	case ea > eb:
+		return 1
+	}
+
+	return 0
+}
+
+// rpmvercmp compares two version or release strings following the algorithm of rpmvercmp
+// from librpm, including the handling of ~ (sorts before anything) and ^ (sorts after the
+// base version but before anything else).
+func rpmvercmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && !isRPMAlnum(a[i]) && a[i] != '~' && a[i] != '^' {
+			i++
+		}
+		for j < len(b) && !isRPMAlnum(b[j]) && b[j] != '~' && b[j] != '^' {
+			j++
+		}
+
+		if (i < len(a) && a[i] == '~') || (j < len(b) && b[j] == '~') {
+			if i >= len(a) || a[i] != '~' {
+				return 1
+			}
+			if j >= len(b) || b[j] != '~' {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+
+		if (i < len(a) && a[i] == '^') || (j < len(b) && b[j] == '^') {
+			if i >= len(a) {
+				return -1
+			}
+			if j >= len(b) {
+				return 1
+			}
+			if a[i] != '^' {
+				return 1
+			}
+			if b[j] != '^' {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+
+		if i >= len(a) || j >= len(b) {
+			break
+		}
+
+		startA, startB := i, j
+		isNum := isRPMDigit(a[i])
+		if isNum {
+			for i < len(a) && isRPMDigit(a[i]) {
+				i++
+			}
+			for j < len(b) && isRPMDigit(b[j]) {
+				j++
+			}
+		} else {
+			for i < len(a) && isRPMAlpha(a[i]) {
+				i++
+			}
+			for j < len(b) && isRPMAlpha(b[j]) {
+				j++
+			}
+		}
+
+		segA, segB := a[startA:i], b[startB:j]
+
+		// Segments of different types: numeric segments are newer than alpha ones
+		if segB == "" {
+			if isNum {
+				return 1
+			}
+			return -1
+		}
+
+		if isNum {
+			segA = strings.TrimLeft(segA, "0")
+			segB = strings.TrimLeft(segB, "0")
+
+			if len(segA) > len(segB) {
+				return 1
+			}
+			if len(segB) > len(segA) {
+				return -1
+			}
+		}
+
+		if c := strings.Compare(segA, segB); c != 0 {
+			return c
+		}
+	}
+
+	if i >= len(a) && j >= len(b) {
+		return 0
+	}
+	if i >= len(a) {
+		return -1
+	}
+
+	return 1
+}
+
+func isRPMDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isRPMAlpha(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isRPMAlnum(c byte) bool {
+	return isRPMDigit(c) || isRPMAlpha(c)
+}
@@ -0,0 +1,205 @@
+package gosatellite
+
+import "testing"
+
+// The cases are those of the rpmvercmp tests of librpm
+func TestRPMVerCmp(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.0", "2.0", -1},
+		{"2.0", "1.0", 1},
+		{"2.0.1", "2.0.1", 0},
+		{"2.0", "2.0.1", -1},
+		{"2.0.1", "2.0", 1},
+		{"2.0.1a", "2.0.1a", 0},
+		{"2.0.1a", "2.0.1", 1},
+		{"2.0.1", "2.0.1a", -1},
+		{"5.5p1", "5.5p1", 0},
+		{"5.5p1", "5.5p2", -1},
+		{"5.5p2", "5.5p1", 1},
+		{"5.5p10", "5.5p10", 0},
+		{"5.5p1", "5.5p10", -1},
+		{"5.5p10", "5.5p1", 1},
+		{"10xyz", "10.1xyz", -1},
+		{"10.1xyz", "10xyz", 1},
+		{"xyz10", "xyz10", 0},
+		{"xyz10", "xyz10.1", -1},
+		{"xyz10.1", "xyz10", 1},
+		{"xyz.4", "xyz.4", 0},
+		{"xyz.4", "8", -1},
+		{"8", "xyz.4", 1},
+		{"xyz.4", "2", -1},
+		{"2", "xyz.4", 1},
+		{"5.5p2", "5.6p1", -1},
+		{"5.6p1", "5.5p2", 1},
+		{"5.6p1", "6.5p1", -1},
+		{"6.5p1", "5.6p1", 1},
+		{"6.0.rc1", "6.0", 1},
+		{"6.0", "6.0.rc1", -1},
+		{"10b2", "10a1", 1},
+		{"10a2", "10b2", -1},
+		{"1.0aa", "1.0aa", 0},
+		{"1.0a", "1.0aa", -1},
+		{"1.0aa", "1.0a", 1},
+		{"10.0001", "10.0001", 0},
+		{"10.0001", "10.1", 0},
+		{"10.1", "10.0001", 0},
+		{"10.0001", "10.0039", -1},
+		{"10.0039", "10.0001", 1},
+		{"4.999.9", "5.0", -1},
+		{"5.0", "4.999.9", 1},
+		{"20101121", "20101121", 0},
+		{"20101121", "20101122", -1},
+		{"20101122", "20101121", 1},
+		{"2_0", "2_0", 0},
+		{"2.0", "2_0", 0},
+		{"2_0", "2.0", 0},
+		{"a", "a", 0},
+		{"a+", "a+", 0},
+		{"a+", "a_", 0},
+		{"a_", "a+", 0},
+		{"+a", "+a", 0},
+		{"+a", "_a", 0},
+		{"_a", "+a", 0},
+		{"+_", "+_", 0},
+		{"_+", "+_", 0},
+		{"_+", "_+", 0},
+		{"+", "_", 0},
+		{"_", "+", 0},
+		{"1.0~rc1", "1.0~rc1", 0},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0", "1.0~rc1", 1},
+		{"1.0~rc1", "1.0~rc2", -1},
+		{"1.0~rc2", "1.0~rc1", 1},
+		{"1.0~rc1~git123", "1.0~rc1~git123", 0},
+		{"1.0~rc1~git123", "1.0~rc1", -1},
+		{"1.0~rc1", "1.0~rc1~git123", 1},
+		{"1.0^", "1.0^", 0},
+		{"1.0^", "1.0", 1},
+		{"1.0", "1.0^", -1},
+		{"1.0^git1", "1.0^git1", 0},
+		{"1.0^git1", "1.0", 1},
+		{"1.0", "1.0^git1", -1},
+		{"1.0^git1", "1.0^git2", -1},
+		{"1.0^git2", "1.0^git1", 1},
+		{"1.0^git1", "1.01", -1},
+		{"1.01", "1.0^git1", 1},
+		{"1.0^20160101", "1.0^20160101", 0},
+		{"1.0^20160101", "1.0.1", -1},
+		{"1.0.1", "1.0^20160101", 1},
+		{"1.0^20160101^git1", "1.0^20160101^git1", 0},
+		{"1.0^20160102", "1.0^20160101^git1", 1},
+		{"1.0^20160101^git1", "1.0^20160102", -1},
+		{"1.0~rc1^git1", "1.0~rc1^git1", 0},
+		{"1.0~rc1^git1", "1.0~rc1", 1},
+		{"1.0~rc1", "1.0~rc1^git1", -1},
+		{"1.0^git1~pre", "1.0^git1~pre", 0},
+		{"1.0^git1", "1.0^git1~pre", 1},
+		{"1.0^git1~pre", "1.0^git1", -1},
+		{"1b.fc17", "1b.fc17", 0},
+		{"1b.fc17", "1.fc17", -1},
+		{"1.fc17", "1b.fc17", 1},
+		{"1g.fc17", "1g.fc17", 0},
+		{"1g.fc17", "1.fc17", 1},
+		{"1.fc17", "1g.fc17", -1},
+	}
+
+	for _, tt := range tests {
+		if got := rpmvercmp(tt.a, tt.b); got != tt.want {
+			t.Errorf("rpmvercmp(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestParseNVRA(t *testing.T) {
+	tests := []struct {
+		in   string
+		want NVRA
+	}{
+		{"bash-5.1.8-6.el9.x86_64", NVRA{Name: "bash", Version: "5.1.8", Release: "6.el9", Arch: "x86_64"}},
+		{"bash-5.1.8-6.el9.x86_64.rpm", NVRA{Name: "bash", Version: "5.1.8", Release: "6.el9", Arch: "x86_64"}},
+		{"NetworkManager-1:1.42.2-1.el9.x86_64", NVRA{Name: "NetworkManager", Epoch: "1", Version: "1.42.2", Release: "1.el9", Arch: "x86_64"}},
+		{"1:NetworkManager-1.42.2-1.el9.x86_64", NVRA{Name: "NetworkManager", Epoch: "1", Version: "1.42.2", Release: "1.el9", Arch: "x86_64"}},
+		{"python3-dnf-plugins-core-4.3.0-5.el9.noarch", NVRA{Name: "python3-dnf-plugins-core", Version: "4.3.0", Release: "5.el9", Arch: "noarch"}},
+		{"kernel-0:5.14.0-284.11.1.el9_2.x86_64", NVRA{Name: "kernel", Epoch: "0", Version: "5.14.0", Release: "284.11.1.el9_2", Arch: "x86_64"}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseNVRA(tt.in)
+		if err != nil {
+			t.Errorf("ParseNVRA(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseNVRA(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseNVRAErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"bash",
+		"bash.x86_64",
+		"bash-5.1.8.x86_64",
+		"-5.1.8-6.el9.x86_64",
+		"bash-5.1.8-6.el9.",
+		"bash-x:5.1.8-6.el9.x86_64",
+	}
+
+	for _, in := range tests {
+		if got, err := ParseNVRA(in); err == nil {
+			t.Errorf("ParseNVRA(%q) = %+v, want an error", in, got)
+		}
+	}
+}
+
+func TestNVRACompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"bash-5.1.8-6.el9.x86_64", "bash-5.1.8-6.el9.x86_64", 0},
+		{"bash-5.1.8-6.el9.x86_64", "bash-5.1.8-9.el9.x86_64", -1},
+		{"bash-5.2.15-1.el9.x86_64", "bash-5.1.8-9.el9.x86_64", 1},
+		{"bash-0:5.1.8-6.el9.x86_64", "bash-5.1.8-6.el9.x86_64", 0},
+		{"bash-1:4.0-1.el9.x86_64", "bash-5.1.8-6.el9.x86_64", 1},
+		{"bash-5.1.8-6.el9.x86_64", "bash-2:1.0-1.el9.x86_64", -1},
+		{"kernel-5.14.0-284.11.1.el9_2.x86_64", "kernel-5.14.0-284.el9.x86_64", 1},
+	}
+
+	for _, tt := range tests {
+		a, err := ParseNVRA(tt.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ParseNVRA(tt.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := a.Compare(b); got != tt.want {
+			t.Errorf("%s compared to %s = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestNVRAString(t *testing.T) {
+	tests := []string{
+		"bash-5.1.8-6.el9.x86_64",
+		"NetworkManager-1:1.42.2-1.el9.x86_64",
+	}
+
+	for _, in := range tests {
+		n, err := ParseNVRA(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := n.String(); got != in {
+			t.Errorf("ParseNVRA(%q).String() = %q", in, got)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package gosatellite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const packageGroupsPath = katelloBasePath + "/package_groups"
+
+// PackageGroup defines model for a Package Group.
+type PackageGroup struct {
+	Description *string          `json:"description"`
+	ID          *int             `json:"id"`
+	Name        *string          `json:"name"`
+	PulpID      *string          `json:"pulp_id"`
+	Repository  *shortRepository `json:"repository"`
+	UUID        *string          `json:"uuid"`
+}
+
+// PackageGroupsList defines model for a list of package groups.
+type PackageGroupsList struct {
+	searchResults
+	Results *[]PackageGroup `json:"results"`
+}
+
+// PackageGroupsListOptions specifies the optional parameters to various List methods that
+// support pagination.
+type PackageGroupsListOptions struct {
+	KatelloListOptions
+
+	// ID of an organization to list package groups from
+	OrganizationID int `url:"organization_id,omitempty"`
+
+	// ID of a repository to list package groups from
+	RepositoryID int `url:"repository_id,omitempty"`
+
+	// ID of a content view version to list package groups from
+	ContentViewVersionID int `url:"content_view_version_id,omitempty"`
+
+	// ID of a content view filter to list package groups from
+	ContentViewFilterID int `url:"content_view_filter_id,omitempty"`
+
+	// ID of a lifecycle environment to list package groups from
+	EnvironmentID int `url:"environment_id,omitempty"`
+}
+
+// PackageGroups is an interface for interacting with
+// Red Hat Satellite Package Groups
+type PackageGroups interface {
+	Get(ctx context.Context, packageGroupID int) (*PackageGroup, *http.Response, error)
+	List(ctx context.Context, opt *PackageGroupsListOptions) (*PackageGroupsList, *http.Response, error)
+}
+
+// PackageGroupsOp handles communication with the Package Group related methods of the
+// Red Hat Satellite REST API
+type PackageGroupsOp struct {
+	client *Client
+}
+
+// Get a single package group by its ID
+func (s *PackageGroupsOp) Get(ctx context.Context, packageGroupID int) (*PackageGroup, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", packageGroupsPath, packageGroupID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	group := new(PackageGroup)
+	resp, err := s.client.Do(ctx, req, group)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return group, resp, err
+}
+
+// List all package groups or a filtered list of package groups. There is no filter by host.
+func (s *PackageGroupsOp) List(ctx context.Context, opt *PackageGroupsListOptions) (*PackageGroupsList, *http.Response, error) {
+	path := packageGroupsPath
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(PackageGroupsList)
+	resp, err := s.client.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, err
+}
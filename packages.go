@@ -0,0 +1,155 @@
+package gosatellite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const packagesPath = katelloBasePath + "/packages"
+
+// Package defines model for an RPM Package.
+type Package struct {
+	Arch                 *string            `json:"arch"`
+	BuildHost            *string            `json:"build_host"`
+	BuildTime            *string            `json:"build_time"`
+	Checksum             *string            `json:"checksum"`
+	Description          *string            `json:"description"`
+	Epoch                *string            `json:"epoch"`
+	Filename             *string            `json:"filename"`
+	HostsApplicableCount *int               `json:"hosts_applicable_count"`
+	HostsAvailableCount  *int               `json:"hosts_available_count"`
+	ID                   *int               `json:"id"`
+	Modular              *bool              `json:"modular"`
+	Name                 *string            `json:"name"`
+	NVRA                 *string            `json:"nvra"`
+	NVREA                *string            `json:"nvrea"`
+	PulpID               *string            `json:"pulp_id"`
+	Release              *string            `json:"release"`
+	Repositories         *[]shortRepository `json:"repositories"`
+	Size                 *int               `json:"size"`
+	SourceRPM            *string            `json:"sourcerpm"`
+	Summary              *string            `json:"summary"`
+	URL                  *string            `json:"url"`
+	UUID                 *string            `json:"uuid"`
+	Version              *string            `json:"version"`
+}
+
+// ParsedNVRA returns the name, epoch, version, release and architecture of the package
+// so that it can be compared with others
+func (p *Package) ParsedNVRA() NVRA {
+	var n NVRA
+
+	if p.Name != nil {
+		n.Name = *p.Name
+	}
+	if p.Epoch != nil {
+		n.Epoch = *p.Epoch
+	}
+	if p.Version != nil {
+		n.Version = *p.Version
+	}
+	if p.Release != nil {
+		n.Release = *p.Release
+	}
+	if p.Arch != nil {
+		n.Arch = *p.Arch
+	}
+
+	return n
+}
+
+// PackagesList defines model for a list of packages.
+type PackagesList struct {
+	searchResults
+	Results *[]Package `json:"results"`
+}
+
+// PackagesListOptions specifies the optional parameters to various List methods that
+// support pagination.
+type PackagesListOptions struct {
+	KatelloListOptions
+
+	// ID of an organization to list packages from
+	OrganizationID int `url:"organization_id,omitempty"`
+
+	// ID of a repository to list packages from
+	RepositoryID int `url:"repository_id,omitempty"`
+
+	// ID of a content view version to list packages from
+	ContentViewVersionID int `url:"content_view_version_id,omitempty"`
+
+	// ID of a content view filter to list packages from
+	ContentViewFilterID int `url:"content_view_filter_id,omitempty"`
+
+	// ID of a lifecycle environment to list packages from
+	EnvironmentID int `url:"environment_id,omitempty"`
+
+	// ID of a host to list packages from
+	HostID int `url:"host_id,omitempty"`
+
+	// If true, only return packages installed on the host
+	PackagesRestrictInstalled bool `url:"packages_restrict_installed,omitempty"`
+
+	// If true, only return packages applicable to the host
+	PackagesRestrictApplicable bool `url:"packages_restrict_applicable,omitempty"`
+
+	// If true, only return packages that are upgrades of installed packages of the host
+	PackagesRestrictUpgradable bool `url:"packages_restrict_upgradable,omitempty"`
+
+	// If true, only return the latest version of each package
+	PackagesRestrictLatest bool `url:"packages_restrict_latest,omitempty"`
+}
+
+// Packages is an interface for interacting with
+// Red Hat Satellite Packages
+type Packages interface {
+	Get(ctx context.Context, packageID int) (*Package, *http.Response, error)
+	List(ctx context.Context, opt *PackagesListOptions) (*PackagesList, *http.Response, error)
+}
+
+// PackagesOp handles communication with the Package related methods of the
+// Red Hat Satellite REST API
+type PackagesOp struct {
+	client *Client
+}
+
+// Get a single package by its ID
+func (s *PackagesOp) Get(ctx context.Context, packageID int) (*Package, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", packagesPath, packageID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pkg := new(Package)
+	resp, err := s.client.Do(ctx, req, pkg)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return pkg, resp, err
+}
+
+// List all packages or a filtered list of packages
+func (s *PackagesOp) List(ctx context.Context, opt *PackagesListOptions) (*PackagesList, *http.Response, error) {
+	path := packagesPath
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(PackagesList)
+	resp, err := s.client.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, err
+}
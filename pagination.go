@@ -0,0 +1,175 @@
+package gosatellite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// Page is implemented by every list model returned from the List methods. It
+// gives the pagination helpers access to the search results metadata of a page.
+type Page interface {
+	pageInfo() *searchResults
+}
+
+func (r *searchResults) pageInfo() *searchResults {
+	return r
+}
+
+// PageFunc fetches a single page of results. It is called with the page number
+// to retrieve, which should be set on the Page field of the list options before
+// calling the List method, e.g.
+//
+//	func(ctx context.Context, page int) (gosatellite.Page, *http.Response, error) {
+//		opt.Page = page
+//		return client.Repositories.List(ctx, opt)
+//	}
+type PageFunc func(ctx context.Context, page int) (Page, *http.Response, error)
+
+// PageIterator lazily walks through all the pages of a list by calling a PageFunc
+// until the number of results reported by the API has been reached. It also stops on
+// an empty or short page, or when the API returns the previous page again, so that
+// it ends even when the subtotal is missing or the page parameter is ignored.
+type PageIterator struct {
+	fetch PageFunc
+	next  int
+	seen  int
+	done  bool
+	page  Page
+	resp  *http.Response
+	err   error
+}
+
+// NewPageIterator returns a PageIterator that starts at the first page
+func NewPageIterator(fetch PageFunc) *PageIterator {
+	return &PageIterator{fetch: fetch, next: 1}
+}
+
+// Next fetches the next page of results. It returns false when there are no more pages,
+// the context has been cancelled or an error occurred. Err should be checked once Next
+// returns false.
+func (it *PageIterator) Next(ctx context.Context) bool {
+	if it.done {
+		return false
+	}
+
+	if err := ctx.Err(); err != nil {
+		it.err = err
+		it.done = true
+		return false
+	}
+
+	page, resp, err := it.fetch(ctx, it.next)
+	it.resp = resp
+	if err != nil {
+		it.err = err
+		it.done = true
+		return false
+	}
+
+	count := pageLength(page)
+	if count == 0 || it.repeats(page) {
+		it.done = true
+		return false
+	}
+
+	it.page = page
+	it.seen += count
+	it.next++
+
+	info := page.pageInfo()
+	if info.Subtotal != nil && it.seen >= *info.Subtotal {
+		it.done = true
+	}
+	if info.PerPage != nil && count < *info.PerPage {
+		it.done = true
+	}
+
+	return true
+}
+
+// repeats returns whether page holds the same results as the previous page
+func (it *PageIterator) repeats(page Page) bool {
+	if it.page == nil {
+		return false
+	}
+
+	return reflect.DeepEqual(pageResults(it.page).Interface(), pageResults(page).Interface())
+}
+
+// Page returns the page of results fetched by the last call to Next
+func (it *PageIterator) Page() Page {
+	return it.page
+}
+
+// Response returns the HTTP response of the last page fetched
+func (it *PageIterator) Response() *http.Response {
+	return it.resp
+}
+
+// Err returns the error, if any, that stopped the iteration
+func (it *PageIterator) Err() error {
+	return it.err
+}
+
+// All walks through every page returned by fetch and appends the results of each page
+// to the slice pointed to by v. The element type of v must match the element type of the
+// Results field of the list model, e.g. a *[]Repository for a RepositoriesList.
+func All(ctx context.Context, fetch PageFunc, v interface{}) error {
+	out := reflect.ValueOf(v)
+	if out.Kind() != reflect.Ptr || out.IsNil() || out.Elem().Kind() != reflect.Slice {
+		return NewArgError("v", "must be a non-nil pointer to a slice")
+	}
+
+	it := NewPageIterator(fetch)
+	for it.Next(ctx) {
+		results := pageResults(it.Page())
+		if results.Type() != out.Elem().Type() {
+			return NewArgError("v", fmt.Sprintf("must be a pointer to %s", results.Type()))
+		}
+		out.Elem().Set(reflect.AppendSlice(out.Elem(), results))
+	}
+
+	return it.Err()
+}
+
+// pageResults returns the slice held in the Results field of a list model
+func pageResults(page Page) reflect.Value {
+	v := reflect.Indirect(reflect.ValueOf(page))
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+
+	results := v.FieldByName("Results")
+	if !results.IsValid() {
+		return reflect.Value{}
+	}
+
+	if results.Kind() == reflect.Ptr {
+		if results.IsNil() {
+			return reflect.Value{}
+		}
+		results = results.Elem()
+	}
+
+	if results.Kind() != reflect.Slice {
+		return reflect.Value{}
+	}
+
+	return results
+}
+
+// pageLength returns the number of results in a page
+func pageLength(page Page) int {
+	if page == nil || reflect.ValueOf(page).IsNil() {
+		return 0
+	}
+
+	results := pageResults(page)
+	if !results.IsValid() {
+		return 0
+	}
+
+	return results.Len()
+}
@@ -0,0 +1,152 @@
+package gosatellite
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// pages returns a PageFunc serving organizations by pages of perPage. Pages past the
+// end are empty. When ignorePage is set, the first page is returned for every page.
+func pages(ids []int, perPage int, subtotal *int, ignorePage bool, calls *int) PageFunc {
+	return func(ctx context.Context, page int) (Page, *http.Response, error) {
+		*calls++
+		if ignorePage {
+			page = 1
+		}
+
+		results := []OrganizationShort{}
+		for i := (page - 1) * perPage; i < page*perPage && i < len(ids); i++ {
+			results = append(results, OrganizationShort{ID: Int(ids[i])})
+		}
+
+		list := &OrganizationsList{Results: &results}
+		list.Page = Int(page)
+		list.PerPage = Int(perPage)
+		list.Subtotal = subtotal
+
+		return list, nil, nil
+	}
+}
+
+func TestPageIterator(t *testing.T) {
+	tests := []struct {
+		name       string
+		ids        []int
+		perPage    int
+		subtotal   *int
+		ignorePage bool
+		want       []int
+		wantCalls  int
+	}{
+		{
+			name:      "multiple pages",
+			ids:       []int{1, 2, 3, 4, 5},
+			perPage:   2,
+			subtotal:  Int(5),
+			want:      []int{1, 2, 3, 4, 5},
+			wantCalls: 3,
+		},
+		{
+			name:      "full last page",
+			ids:       []int{1, 2, 3, 4},
+			perPage:   2,
+			subtotal:  Int(4),
+			want:      []int{1, 2, 3, 4},
+			wantCalls: 2,
+		},
+		{
+			name:      "no results",
+			perPage:   2,
+			subtotal:  Int(0),
+			want:      []int{},
+			wantCalls: 1,
+		},
+		{
+			name:      "nil subtotal stops on a short page",
+			ids:       []int{1, 2, 3},
+			perPage:   2,
+			want:      []int{1, 2, 3},
+			wantCalls: 2,
+		},
+		{
+			name:      "nil subtotal stops on an empty page",
+			ids:       []int{1, 2, 3, 4},
+			perPage:   2,
+			want:      []int{1, 2, 3, 4},
+			wantCalls: 3,
+		},
+		{
+			name:       "page ignored",
+			ids:        []int{1, 2, 3, 4},
+			perPage:    2,
+			ignorePage: true,
+			want:       []int{1, 2},
+			wantCalls:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			it := NewPageIterator(pages(tt.ids, tt.perPage, tt.subtotal, tt.ignorePage, &calls))
+
+			got := []int{}
+			for it.Next(context.Background()) {
+				for _, org := range *it.Page().(*OrganizationsList).Results {
+					got = append(got, *org.ID)
+				}
+			}
+
+			if err := it.Err(); err != nil {
+				t.Fatalf("Err returned %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("got %d calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestPageIteratorError(t *testing.T) {
+	wantErr := errors.New("failed")
+	it := NewPageIterator(func(ctx context.Context, page int) (Page, *http.Response, error) {
+		return nil, nil, wantErr
+	})
+
+	if it.Next(context.Background()) {
+		t.Fatal("Next returned true")
+	}
+	if it.Err() != wantErr {
+		t.Errorf("got error %v, want %v", it.Err(), wantErr)
+	}
+	if it.Next(context.Background()) {
+		t.Error("Next returned true after an error")
+	}
+}
+
+func TestAll(t *testing.T) {
+	calls := 0
+	var orgs []OrganizationShort
+	if err := All(context.Background(), pages([]int{1, 2, 3}, 2, Int(3), false, &calls), &orgs); err != nil {
+		t.Fatalf("All returned error: %v", err)
+	}
+
+	if len(orgs) != 3 || *orgs[0].ID != 1 || *orgs[2].ID != 3 {
+		t.Errorf("got %d organizations, want 1 to 3", len(orgs))
+	}
+
+	var wrongType []Repository
+	var argErr *ArgError
+	if err := All(context.Background(), pages([]int{1}, 2, nil, false, &calls), &wrongType); !errors.As(err, &argErr) {
+		t.Errorf("got error %v for a slice of the wrong type, want an *ArgError", err)
+	}
+	if err := All(context.Background(), pages([]int{1}, 2, nil, false, &calls), orgs); !errors.As(err, &argErr) {
+		t.Errorf("got error %v for a slice not passed by pointer, want an *ArgError", err)
+	}
+}
@@ -87,11 +87,43 @@ type ProductsListOptions struct {
 	AvailableFor string `url:"available_for,omitempty"`
 }
 
+// ProductCreate defines model for creating a product.
+type ProductCreate struct {
+	OrganizationID  *int    `json:"organization_id"`
+	Name            *string `json:"name"`
+	Label           *string `json:"label,omitempty"`
+	Description     *string `json:"description,omitempty"`
+	GPGKeyID        *int    `json:"gpg_key_id,omitempty"`
+	SSLCACertID     *int    `json:"ssl_ca_cert_id,omitempty"`
+	SSLClientCertID *int    `json:"ssl_client_cert_id,omitempty"`
+	SSLClientKeyID  *int    `json:"ssl_client_key_id,omitempty"`
+	SyncPlanID      *int    `json:"sync_plan_id,omitempty"`
+}
+
+// ProductUpdate defines model for updating a product.
+type ProductUpdate struct {
+	Name            *string `json:"name,omitempty"`
+	Description     *string `json:"description,omitempty"`
+	GPGKeyID        *int    `json:"gpg_key_id,omitempty"`
+	SSLCACertID     *int    `json:"ssl_ca_cert_id,omitempty"`
+	SSLClientCertID *int    `json:"ssl_client_cert_id,omitempty"`
+	SSLClientKeyID  *int    `json:"ssl_client_key_id,omitempty"`
+	SyncPlanID      *int    `json:"sync_plan_id,omitempty"`
+}
+
 // Products is an interface for interacting with
 // Red Hat Satellite products
 type Products interface {
+	BulkDelete(ctx context.Context, productIDs []int) (*Task, *http.Response, error)
+	BulkSync(ctx context.Context, productIDs []int) (*Task, *http.Response, error)
+	BulkUpdateSyncPlan(ctx context.Context, productIDs []int, syncPlanID int) (*Task, *http.Response, error)
+	Create(ctx context.Context, productCreate ProductCreate) (*Product, *http.Response, error)
+	Delete(ctx context.Context, productID int) (*Task, *http.Response, error)
+	Get(ctx context.Context, productID int) (*Product, *http.Response, error)
 	ListByOrgID(ctx context.Context, orgID int, opt *ProductsListOptions) (*ProductsList, *http.Response, error)
 	List(ctx context.Context, opt *ProductsListOptions) (*ProductsList, *http.Response, error)
+	Sync(ctx context.Context, productID int) (*Task, *http.Response, error)
+	Update(ctx context.Context, productID int, productUpdate ProductUpdate) (*Product, *http.Response, error)
 }
 
 // ProductsOp handles communication with the Product related methods of the
@@ -100,6 +132,97 @@ type ProductsOp struct {
 	client *Client
 }
 
+// BulkDelete deletes several products at once
+func (s *ProductsOp) BulkDelete(ctx context.Context, productIDs []int) (*Task, *http.Response, error) {
+	path := productsPath + "/bulk/destroy"
+
+	return s.bulkRequest(ctx, path, productIDs, nil)
+}
+
+// Performs a bulk action request given a path.
+func (s *ProductsOp) bulkRequest(ctx context.Context, path string, productIDs []int, syncPlanID *int) (*Task, *http.Response, error) {
+	if len(productIDs) < 1 {
+		return nil, nil, NewArgError("productIDs", "cannot be empty")
+	}
+
+	var body struct {
+		IDs    []int `json:"ids"`
+		PlanID *int  `json:"plan_id,omitempty"`
+	}
+
+	body.IDs = productIDs
+	body.PlanID = syncPlanID
+
+	return s.taskRequest(ctx, http.MethodPut, path, body)
+}
+
+// BulkSync syncs the repositories of several products at once
+func (s *ProductsOp) BulkSync(ctx context.Context, productIDs []int) (*Task, *http.Response, error) {
+	path := productsPath + "/bulk/sync"
+
+	return s.bulkRequest(ctx, path, productIDs, nil)
+}
+
+// BulkUpdateSyncPlan assigns a sync plan to several products at once
+func (s *ProductsOp) BulkUpdateSyncPlan(ctx context.Context, productIDs []int, syncPlanID int) (*Task, *http.Response, error) {
+	path := productsPath + "/bulk/sync_plan"
+
+	return s.bulkRequest(ctx, path, productIDs, &syncPlanID)
+}
+
+// Create a new product
+func (s *ProductsOp) Create(ctx context.Context, productCreate ProductCreate) (*Product, *http.Response, error) {
+	path := productsPath
+
+	if productCreate.OrganizationID == nil {
+		return nil, nil, NewArgError("productCreate.OrganizationID", "cannot be empty")
+	}
+
+	if productCreate.Name == nil {
+		return nil, nil, NewArgError("productCreate.Name", "cannot be empty")
+	} else if *productCreate.Name == "" {
+		return nil, nil, NewArgError("productCreate.Name", "cannot be empty")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, productCreate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	product := new(Product)
+	resp, err := s.client.Do(ctx, req, product)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return product, resp, err
+}
+
+// Delete a product and its repositories by its ID
+func (s *ProductsOp) Delete(ctx context.Context, productID int) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", productsPath, productID)
+
+	return s.taskRequest(ctx, http.MethodDelete, path, nil)
+}
+
+// Get a single product by its ID
+func (s *ProductsOp) Get(ctx context.Context, productID int) (*Product, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", productsPath, productID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	product := new(Product)
+	resp, err := s.client.Do(ctx, req, product)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return product, resp, err
+}
+
 // Performs a list request given a path.
 func (s *ProductsOp) list(ctx context.Context, path string) (*ProductsList, *http.Response, error) {
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
@@ -137,3 +260,48 @@ func (s *ProductsOp) List(ctx context.Context, opt *ProductsListOptions) (*Produ
 
 	return s.list(ctx, path)
 }
+
+// Sync all the repositories of a product
+func (s *ProductsOp) Sync(ctx context.Context, productID int) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/sync", productsPath, productID)
+
+	return s.taskRequest(ctx, http.MethodPost, path, nil)
+}
+
+// Performs a request given a path that returns a task.
+func (s *ProductsOp) taskRequest(ctx context.Context, method, path string, body interface{}) (*Task, *http.Response, error) {
+	req, err := s.client.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	task := new(Task)
+	resp, err := s.client.Do(ctx, req, task)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return task, resp, err
+}
+
+// Update a product
+func (s *ProductsOp) Update(ctx context.Context, productID int, productUpdate ProductUpdate) (*Product, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", productsPath, productID)
+
+	if productUpdate.Name != nil && *productUpdate.Name == "" {
+		return nil, nil, NewArgError("productUpdate.Name", "cannot be an empty string")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPut, path, productUpdate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	product := new(Product)
+	resp, err := s.client.Do(ctx, req, product)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return product, resp, err
+}
@@ -1,13 +1,33 @@
 package gosatellite
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 const repositoriesPath = katelloBasePath + "/repositories"
 
+const defaultUploadChunkSize = 2 * 1024 * 1024
+
+// Time allowed for deleting a content upload once it has been imported or has failed
+const uploadCleanupTimeout = 30 * time.Second
+
+// Content types of repositories
+const (
+	RepositoryContentTypeYum               = "yum"
+	RepositoryContentTypeDocker            = "docker"
+	RepositoryContentTypeFile              = "file"
+	RepositoryContentTypeAnsibleCollection = "ansible_collection"
+)
+
 type repoContentView struct {
 	ID   *int    `json:"id"`
 	Name *string `json:"name"`
@@ -77,60 +97,51 @@ type Repository struct {
 	ContentLabel                    *string            `json:"content_label"`
 	ContentType                     *string            `json:"content_type"`
 	ContentView                     *repoContentView   `json:"content_view"`
-	ContentViewVersionID            *string            `json:"content_view_version_id"`
+	ContentViewVersionID            *int               `json:"content_view_version_id"`
 	CreatedAt                       *string            `json:"created_at"`
-	//"deb_architectures": null,
-	//"deb_components": null,
-	//"deb_releases": null,
-	Description *string `json:"description"`
-	// "docker_tags_whitelist": null,
-	// "docker_upstream_name": null,
-	DownloadPolicy *string          `json:"download_policy"`
-	Environment    *repoEnvironment `json:"environment"`
-	FullPath       *string          `json:"full_path"`
-	//"gpg_key": null,
-	//"gpg_key_id": null,
-	ID *int `json:"id"`
-	//"ignorable_content": null,
-	IgnoreGlobalProxy *bool         `json:"ignore_global_proxy"`
-	Label             *string       `json:"label"`
-	LastSync          *repoLastSync `json:"last_sync"`
-	LastSyncWords     *string       `json:"last_sync_words"`
-	//"library_instance_id": null,
-	Major        *int      `json:"major"`
-	Minor        *string   `json:"minor"`
-	MirrorOnSync *bool     `json:"mirror_on_sync"`
-	Name         *int      `json:"name"`
-	Organization *shortOrg `json:"organization"`
+	DebArchitectures                *string            `json:"deb_architectures"`
+	DebComponents                   *string            `json:"deb_components"`
+	DebReleases                     *string            `json:"deb_releases"`
+	Description                     *string            `json:"description"`
+	DockerTagsWhitelist             *[]string          `json:"docker_tags_whitelist"`
+	DockerUpstreamName              *string            `json:"docker_upstream_name"`
+	DownloadPolicy                  *string            `json:"download_policy"`
+	Environment                     *repoEnvironment   `json:"environment"`
+	FullPath                        *string            `json:"full_path"`
+	GPGKey                          *genericShortRef   `json:"gpg_key"`
+	GPGKeyID                        *int               `json:"gpg_key_id"`
+	ID                              *int               `json:"id"`
+	IgnorableContent                *[]string          `json:"ignorable_content"`
+	IgnoreGlobalProxy               *bool              `json:"ignore_global_proxy"`
+	Label                           *string            `json:"label"`
+	LastSync                        *repoLastSync      `json:"last_sync"`
+	LastSyncWords                   *string            `json:"last_sync_words"`
+	LibraryInstanceID               *int               `json:"library_instance_id"`
+	Major                           *int               `json:"major"`
+	Minor                           *string            `json:"minor"`
+	MirrorOnSync                    *bool              `json:"mirror_on_sync"`
+	Name                            *string            `json:"name"`
+	Organization                    *shortOrg          `json:"organization"`
 	//"ostree_branches": [],
 	//"ostree_upstream_sync_depth": null,
 	//"ostree_upstream_sync_policy": null,
-	Permissions  *repoPermissions `json:"permissions"`
-	Product      *repoProduct     `json:"product"`
-	Promoted     *bool            `json:"promoted"`
-	RelativePath *string          `json:"relative_path"`
-	// "ssl_ca_cert": {
-	//     "id": null,
-	//     "name": null
-	// },
-	// "ssl_ca_cert_id": null,
-	// "ssl_client_cert": {
-	//     "id": null,
-	//     "name": null
-	// },
-	// "ssl_client_cert_id": null,
-	// "ssl_client_key": {
-	//     "id": null,
-	//     "name": null
-	// },
-	// "ssl_client_key_id": null,
-	Unprotected            *bool   `json:"unprotected"`
-	UpdatedAt              *string `json:"updated_at"`
-	UpstreamAuthExists     *bool   `json:"upstream_auth_exists"`
-	UpstreamPasswordExists *bool   `json:"upstream_password_exists"`
-	//"upstream_username": null,
-	URL             *string `json:"url"`
-	VerifySSLOnSync *bool   `json:"verify_ssl_on_sync"`
+	Permissions            *repoPermissions `json:"permissions"`
+	Product                *repoProduct     `json:"product"`
+	Promoted               *bool            `json:"promoted"`
+	RelativePath           *string          `json:"relative_path"`
+	SSLCACert              *genericShortRef `json:"ssl_ca_cert"`
+	SSLCACertID            *int             `json:"ssl_ca_cert_id"`
+	SSLClientCert          *genericShortRef `json:"ssl_client_cert"`
+	SSLClientCertID        *int             `json:"ssl_client_cert_id"`
+	SSLClientKey           *genericShortRef `json:"ssl_client_key"`
+	SSLClientKeyID         *int             `json:"ssl_client_key_id"`
+	Unprotected            *bool            `json:"unprotected"`
+	UpdatedAt              *string          `json:"updated_at"`
+	UpstreamAuthExists     *bool            `json:"upstream_auth_exists"`
+	UpstreamPasswordExists *bool            `json:"upstream_password_exists"`
+	UpstreamUsername       *string          `json:"upstream_username"`
+	URL                    *string          `json:"url"`
+	VerifySSLOnSync        *bool            `json:"verify_ssl_on_sync"`
 }
 
 // RepositoriesList defines model for a list of repositories.
@@ -206,11 +217,251 @@ type RepositoriesListOptions struct {
 	WithContent string `url:"with_content,omitempty"`
 }
 
+// RepositoryCreate defines model for the settings shared by all types of repositories when
+// creating a repository. It is embedded in the typed create models such as YumRepositoryCreate.
+type RepositoryCreate struct {
+	ProductID        *int    `json:"product_id"`
+	Name             *string `json:"name"`
+	Label            *string `json:"label,omitempty"`
+	Description      *string `json:"description,omitempty"`
+	URL              *string `json:"url,omitempty"`
+	MirrorOnSync     *bool   `json:"mirror_on_sync,omitempty"`
+	VerifySSLOnSync  *bool   `json:"verify_ssl_on_sync,omitempty"`
+	Unprotected      *bool   `json:"unprotected,omitempty"`
+	GPGKeyID         *int    `json:"gpg_key_id,omitempty"`
+	SSLCACertID      *int    `json:"ssl_ca_cert_id,omitempty"`
+	SSLClientCertID  *int    `json:"ssl_client_cert_id,omitempty"`
+	SSLClientKeyID   *int    `json:"ssl_client_key_id,omitempty"`
+	UpstreamUsername *string `json:"upstream_username,omitempty"`
+	UpstreamPassword *string `json:"upstream_password,omitempty"`
+	HTTPProxyPolicy  *string `json:"http_proxy_policy,omitempty"`
+	HTTPProxyID      *int    `json:"http_proxy_id,omitempty"`
+}
+
+func (r RepositoryCreate) repositoryCreate() RepositoryCreate {
+	return r
+}
+
+// RepositoryCreateBody is implemented by the typed models used to create a repository
+type RepositoryCreateBody interface {
+	// ContentType returns the content type of the repository being created
+	ContentType() string
+
+	repositoryCreate() RepositoryCreate
+}
+
+// YumRepositoryCreate defines model for creating a yum repository.
+type YumRepositoryCreate struct {
+	RepositoryCreate
+	ChecksumType     *string   `json:"checksum_type,omitempty"`
+	DownloadPolicy   *string   `json:"download_policy,omitempty"`
+	IgnorableContent *[]string `json:"ignorable_content,omitempty"`
+	Arch             *string   `json:"arch,omitempty"`
+}
+
+// ContentType of a yum repository
+func (r YumRepositoryCreate) ContentType() string {
+	return RepositoryContentTypeYum
+}
+
+// MarshalJSON adds the content type to the body of the request
+func (r YumRepositoryCreate) MarshalJSON() ([]byte, error) {
+	type repo YumRepositoryCreate
+	return json.Marshal(struct {
+		repo
+		ContentType string `json:"content_type"`
+	}{repo(r), r.ContentType()})
+}
+
+// DockerRepositoryCreate defines model for creating a container image repository.
+type DockerRepositoryCreate struct {
+	RepositoryCreate
+	DockerUpstreamName  *string   `json:"docker_upstream_name,omitempty"`
+	DockerTagsWhitelist *[]string `json:"docker_tags_whitelist,omitempty"`
+	DownloadPolicy      *string   `json:"download_policy,omitempty"`
+}
+
+// ContentType of a container image repository
+func (r DockerRepositoryCreate) ContentType() string {
+	return RepositoryContentTypeDocker
+}
+
+// MarshalJSON adds the content type to the body of the request
+func (r DockerRepositoryCreate) MarshalJSON() ([]byte, error) {
+	type repo DockerRepositoryCreate
+	return json.Marshal(struct {
+		repo
+		ContentType string `json:"content_type"`
+	}{repo(r), r.ContentType()})
+}
+
+// FileRepositoryCreate defines model for creating a file repository. The URL of a file
+// repository points to a directory containing a PULP_MANIFEST.
+type FileRepositoryCreate struct {
+	RepositoryCreate
+}
+
+// ContentType of a file repository
+func (r FileRepositoryCreate) ContentType() string {
+	return RepositoryContentTypeFile
+}
+
+// MarshalJSON adds the content type to the body of the request
+func (r FileRepositoryCreate) MarshalJSON() ([]byte, error) {
+	type repo FileRepositoryCreate
+	return json.Marshal(struct {
+		repo
+		ContentType string `json:"content_type"`
+	}{repo(r), r.ContentType()})
+}
+
+// AnsibleCollectionRepositoryCreate defines model for creating an ansible collection repository.
+type AnsibleCollectionRepositoryCreate struct {
+	RepositoryCreate
+	AnsibleCollectionRequirements *string `json:"ansible_collection_requirements,omitempty"`
+	AnsibleCollectionAuthURL      *string `json:"ansible_collection_auth_url,omitempty"`
+	AnsibleCollectionAuthToken    *string `json:"ansible_collection_auth_token,omitempty"`
+}
+
+// ContentType of an ansible collection repository
+func (r AnsibleCollectionRepositoryCreate) ContentType() string {
+	return RepositoryContentTypeAnsibleCollection
+}
+
+// MarshalJSON adds the content type to the body of the request
+func (r AnsibleCollectionRepositoryCreate) MarshalJSON() ([]byte, error) {
+	type repo AnsibleCollectionRepositoryCreate
+	return json.Marshal(struct {
+		repo
+		ContentType string `json:"content_type"`
+	}{repo(r), r.ContentType()})
+}
+
+// RepositoryUpdate defines model for the settings shared by all types of repositories when
+// updating a repository. It is embedded in the typed update models such as YumRepositoryUpdate.
+type RepositoryUpdate struct {
+	Name             *string `json:"name,omitempty"`
+	Description      *string `json:"description,omitempty"`
+	URL              *string `json:"url,omitempty"`
+	MirrorOnSync     *bool   `json:"mirror_on_sync,omitempty"`
+	VerifySSLOnSync  *bool   `json:"verify_ssl_on_sync,omitempty"`
+	Unprotected      *bool   `json:"unprotected,omitempty"`
+	GPGKeyID         *int    `json:"gpg_key_id,omitempty"`
+	SSLCACertID      *int    `json:"ssl_ca_cert_id,omitempty"`
+	SSLClientCertID  *int    `json:"ssl_client_cert_id,omitempty"`
+	SSLClientKeyID   *int    `json:"ssl_client_key_id,omitempty"`
+	UpstreamUsername *string `json:"upstream_username,omitempty"`
+	UpstreamPassword *string `json:"upstream_password,omitempty"`
+	HTTPProxyPolicy  *string `json:"http_proxy_policy,omitempty"`
+	HTTPProxyID      *int    `json:"http_proxy_id,omitempty"`
+}
+
+func (r RepositoryUpdate) repositoryUpdate() RepositoryUpdate {
+	return r
+}
+
+// RepositoryUpdateBody is implemented by the typed models used to update a repository
+type RepositoryUpdateBody interface {
+	repositoryUpdate() RepositoryUpdate
+}
+
+// YumRepositoryUpdate defines model for updating a yum repository.
+type YumRepositoryUpdate struct {
+	RepositoryUpdate
+	ChecksumType     *string   `json:"checksum_type,omitempty"`
+	DownloadPolicy   *string   `json:"download_policy,omitempty"`
+	IgnorableContent *[]string `json:"ignorable_content,omitempty"`
+	Arch             *string   `json:"arch,omitempty"`
+}
+
+// DockerRepositoryUpdate defines model for updating a container image repository.
+type DockerRepositoryUpdate struct {
+	RepositoryUpdate
+	DockerUpstreamName  *string   `json:"docker_upstream_name,omitempty"`
+	DockerTagsWhitelist *[]string `json:"docker_tags_whitelist,omitempty"`
+	DownloadPolicy      *string   `json:"download_policy,omitempty"`
+}
+
+// FileRepositoryUpdate defines model for updating a file repository.
+type FileRepositoryUpdate struct {
+	RepositoryUpdate
+}
+
+// AnsibleCollectionRepositoryUpdate defines model for updating an ansible collection repository.
+type AnsibleCollectionRepositoryUpdate struct {
+	RepositoryUpdate
+	AnsibleCollectionRequirements *string `json:"ansible_collection_requirements,omitempty"`
+	AnsibleCollectionAuthURL      *string `json:"ansible_collection_auth_url,omitempty"`
+	AnsibleCollectionAuthToken    *string `json:"ansible_collection_auth_token,omitempty"`
+}
+
+// RepositorySync defines model for the options of a repository sync.
+type RepositorySync struct {
+	SourceURL         *string `json:"source_url,omitempty"`
+	Incremental       *bool   `json:"incremental,omitempty"`
+	SkipMetadataCheck *bool   `json:"skip_metadata_check,omitempty"`
+	ValidateContents  *bool   `json:"validate_contents,omitempty"`
+}
+
+// RepositoryRemoveContent defines model for removing content units from a repository.
+type RepositoryRemoveContent struct {
+	IDs         *[]int  `json:"ids"`
+	ContentType *string `json:"content_type,omitempty"`
+	SyncCapsule *bool   `json:"sync_capsule,omitempty"`
+}
+
+// RepositoryUploadOptions specifies how content is uploaded to a repository
+type RepositoryUploadOptions struct {
+	// Name of the file being uploaded
+	Filename string
+
+	// Type of the content being uploaded, e.g. rpm or file. Defaults to the type of the repository.
+	ContentType string
+
+	// Total size of the content in bytes, which Katello needs to create the upload. It can
+	// only be left empty when the content is an io.Seeker, the size then being the number of
+	// bytes from its current offset to its end.
+	Size int64
+
+	// Size of the chunks the content is streamed in. Defaults to 2 MiB.
+	ChunkSize int
+
+	// Whether or not to publish the repository after importing the upload
+	PublishRepository *bool
+
+	// Whether or not to sync the repository to the capsules after importing the upload
+	SyncCapsule *bool
+
+	// Optional function called as the content is uploaded with the number of bytes sent so far
+	Progress func(sent int64)
+
+	// How the import of the upload is polled until it finishes
+	PollOptions *TaskPollOptions
+}
+
+type repoContentUpload struct {
+	UploadID *string `json:"upload_id"`
+}
+
+type repoImportUpload struct {
+	ID       string `json:"id"`
+	Name     string `json:"name,omitempty"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+}
+
 // Repositories is an interface for interacting with
 // Red Hat Satellite repositories
 type Repositories interface {
+	Create(ctx context.Context, repoCreate RepositoryCreateBody) (*Repository, *http.Response, error)
+	Delete(ctx context.Context, repoID int) (*http.Response, error)
 	Get(ctx context.Context, repoID int) (*Repository, *http.Response, error)
 	List(ctx context.Context, opt *RepositoriesListOptions) (*RepositoriesList, *http.Response, error)
+	RemoveContent(ctx context.Context, repoID int, removeContent RepositoryRemoveContent) (*Task, *http.Response, error)
+	RepublishMetadata(ctx context.Context, repoID int) (*Task, *http.Response, error)
+	Sync(ctx context.Context, repoID int, repoSync RepositorySync) (*Task, *http.Response, error)
+	Update(ctx context.Context, repoID int, repoUpdate RepositoryUpdateBody) (*Repository, *http.Response, error)
+	Upload(ctx context.Context, repoID int, content io.Reader, opts RepositoryUploadOptions) (*Task, *http.Response, error)
 }
 
 // RepositoriesOp handles communication with the Repository related methods of the
@@ -219,6 +470,56 @@ type RepositoriesOp struct {
 	client *Client
 }
 
+// Create a new repository
+func (s *RepositoriesOp) Create(ctx context.Context, repoCreate RepositoryCreateBody) (*Repository, *http.Response, error) {
+	path := repositoriesPath
+
+	if repoCreate == nil {
+		return nil, nil, NewArgError("repoCreate", "cannot be empty")
+	}
+
+	base := repoCreate.repositoryCreate()
+	if base.ProductID == nil {
+		return nil, nil, NewArgError("repoCreate.ProductID", "cannot be empty")
+	}
+
+	if base.Name == nil {
+		return nil, nil, NewArgError("repoCreate.Name", "cannot be empty")
+	} else if *base.Name == "" {
+		return nil, nil, NewArgError("repoCreate.Name", "cannot be empty")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, repoCreate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repo := new(Repository)
+	resp, err := s.client.Do(ctx, req, repo)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return repo, resp, err
+}
+
+// Delete a repository by its ID
+func (s *RepositoriesOp) Delete(ctx context.Context, repoID int) (*http.Response, error) {
+	path := fmt.Sprintf("%s/%d", repositoriesPath, repoID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, err
+}
+
 // Get a single repository by its ID
 func (s *RepositoriesOp) Get(ctx context.Context, repoID int) (*Repository, *http.Response, error) {
 	path := fmt.Sprintf("%s/%d", repositoriesPath, repoID)
@@ -258,3 +559,260 @@ func (s *RepositoriesOp) List(ctx context.Context, opt *RepositoriesListOptions)
 
 	return repositories, resp, err
 }
+
+// RemoveContent removes content units such as packages or container manifests from a repository
+func (s *RepositoriesOp) RemoveContent(ctx context.Context, repoID int, removeContent RepositoryRemoveContent) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/remove_content", repositoriesPath, repoID)
+
+	if removeContent.IDs == nil || len(*removeContent.IDs) < 1 {
+		return nil, nil, NewArgError("removeContent.IDs", "cannot be empty")
+	}
+
+	return s.taskRequest(ctx, http.MethodPut, path, removeContent)
+}
+
+// RepublishMetadata forces the metadata of a repository to be regenerated
+func (s *RepositoriesOp) RepublishMetadata(ctx context.Context, repoID int) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/republish", repositoriesPath, repoID)
+
+	return s.taskRequest(ctx, http.MethodPut, path, nil)
+}
+
+// Sync a repository from its upstream URL
+func (s *RepositoriesOp) Sync(ctx context.Context, repoID int, repoSync RepositorySync) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/sync", repositoriesPath, repoID)
+
+	return s.taskRequest(ctx, http.MethodPost, path, repoSync)
+}
+
+// Performs a request given a path that returns a task.
+func (s *RepositoriesOp) taskRequest(ctx context.Context, method, path string, body interface{}) (*Task, *http.Response, error) {
+	req, err := s.client.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	task := new(Task)
+	resp, err := s.client.Do(ctx, req, task)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return task, resp, err
+}
+
+// Update a repository
+func (s *RepositoriesOp) Update(ctx context.Context, repoID int, repoUpdate RepositoryUpdateBody) (*Repository, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", repositoriesPath, repoID)
+
+	if repoUpdate == nil {
+		return nil, nil, NewArgError("repoUpdate", "cannot be empty")
+	}
+
+	base := repoUpdate.repositoryUpdate()
+	if base.Name != nil && *base.Name == "" {
+		return nil, nil, NewArgError("repoUpdate.Name", "cannot be an empty string")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPut, path, repoUpdate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repo := new(Repository)
+	resp, err := s.client.Do(ctx, req, repo)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return repo, resp, err
+}
+
+// Upload content such as a package or a file to a repository. The content is streamed to the
+// content uploads API in chunks and imported into the repository. Upload waits for the import
+// task to finish, so that the content upload can be deleted once Katello is done with it, and
+// returns the finished task. When the import fails the task is returned with a *TaskError.
+//
+// The content upload is left in place if ctx is done before the import finishes, as Katello
+// may still be reading it.
+func (s *RepositoriesOp) Upload(ctx context.Context, repoID int, content io.Reader, opts RepositoryUploadOptions) (*Task, *http.Response, error) {
+	uploadsPath := fmt.Sprintf("%s/%d/content_uploads", repositoriesPath, repoID)
+
+	if content == nil {
+		return nil, nil, NewArgError("content", "cannot be empty")
+	}
+
+	if opts.Filename == "" {
+		return nil, nil, NewArgError("opts.Filename", "cannot be empty")
+	}
+
+	size := opts.Size
+	if size <= 0 {
+		seeker, ok := content.(io.Seeker)
+		if !ok {
+			return nil, nil, NewArgError("opts.Size", "cannot be empty when content is not an io.Seeker")
+		}
+
+		var err error
+		size, err = remainingSize(seeker)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultUploadChunkSize
+	}
+
+	var body struct {
+		ContentType string `json:"content_type,omitempty"`
+		Size        int64  `json:"size"`
+	}
+
+	body.ContentType = opts.ContentType
+	body.Size = size
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, uploadsPath, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	upload := new(repoContentUpload)
+	resp, err := s.client.Do(ctx, req, upload)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if upload.UploadID == nil {
+		return nil, resp, fmt.Errorf("no upload id returned for content upload to repository %d", repoID)
+	}
+
+	uploadPath := fmt.Sprintf("%s/%s", uploadsPath, *upload.UploadID)
+
+	task, resp, err := s.importUpload(ctx, repoID, *upload.UploadID, uploadPath, content, size, chunkSize, opts)
+	if task != nil && !task.Finished() {
+		return task, resp, fmt.Errorf("%w (content upload %s was not deleted as its import may still be running)", err, *upload.UploadID)
+	}
+
+	if derr := s.deleteUpload(uploadPath); derr != nil {
+		if err != nil {
+			return task, resp, fmt.Errorf("%w (deleting content upload %s also failed: %v)", err, *upload.UploadID, derr)
+		}
+		return task, resp, fmt.Errorf("deleting content upload %s: %w", *upload.UploadID, derr)
+	}
+
+	return task, resp, err
+}
+
+// importUpload sends the content to an upload in chunks, imports the upload into the
+// repository and waits for the import to finish. The returned task is nil when the import
+// was not started.
+func (s *RepositoriesOp) importUpload(ctx context.Context, repoID int, uploadID, uploadPath string, content io.Reader, size int64, chunkSize int, opts RepositoryUploadOptions) (*Task, *http.Response, error) {
+	var resp *http.Response
+
+	hash := sha256.New()
+	chunk := make([]byte, chunkSize)
+	var offset int64
+	for {
+		n, readErr := io.ReadFull(content, chunk)
+		if n > 0 {
+			hash.Write(chunk[:n])
+
+			fields := map[string]string{"offset": strconv.FormatInt(offset, 10)}
+			file := MultipartFile{Field: "content", Filename: opts.Filename, Content: bytes.NewReader(chunk[:n])}
+			if opts.Progress != nil {
+				chunkOffset := offset
+				file.Progress = func(sent int64) { opts.Progress(chunkOffset + sent) }
+			}
+
+			req, err := s.client.NewMultipartRequest(ctx, http.MethodPut, uploadPath, fields, file)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			resp, err = s.client.Do(ctx, req, nil)
+			if err != nil {
+				return nil, resp, err
+			}
+
+			offset += int64(n)
+		}
+
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return nil, nil, readErr
+		}
+	}
+
+	if offset != size {
+		return nil, resp, NewArgError("opts.Size", fmt.Sprintf("is %d but %d bytes of content were read", size, offset))
+	}
+
+	var importBody struct {
+		Uploads           []repoImportUpload `json:"uploads"`
+		ContentType       string             `json:"content_type,omitempty"`
+		PublishRepository *bool              `json:"publish_repository,omitempty"`
+		SyncCapsule       *bool              `json:"sync_capsule,omitempty"`
+	}
+
+	importBody.Uploads = []repoImportUpload{{
+		ID:       uploadID,
+		Name:     opts.Filename,
+		Size:     offset,
+		Checksum: hex.EncodeToString(hash.Sum(nil)),
+	}}
+	importBody.ContentType = opts.ContentType
+	importBody.PublishRepository = opts.PublishRepository
+	importBody.SyncCapsule = opts.SyncCapsule
+
+	importPath := fmt.Sprintf("%s/%d/import_uploads", repositoriesPath, repoID)
+
+	task, resp, err := s.taskRequest(ctx, http.MethodPut, importPath, importBody)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	finished, err := task.Wait(ctx, opts.PollOptions)
+	if finished == nil {
+		return task, resp, err
+	}
+
+	return finished, resp, err
+}
+
+// deleteUpload deletes a content upload. It does not use the context of the upload, which
+// may be done when the upload failed.
+func (s *RepositoriesOp) deleteUpload(uploadPath string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), uploadCleanupTimeout)
+	defer cancel()
+
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, uploadPath, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	return err
+}
+
+// remainingSize returns the number of bytes from the current offset of seeker to its end
+func remainingSize(seeker io.Seeker) (int64, error) {
+	current, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+
+	end, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := seeker.Seek(current, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	return end - current, nil
+}
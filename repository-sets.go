@@ -0,0 +1,249 @@
+package gosatellite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const repositorySetsPath = katelloBasePath + "/repository_sets"
+
+// RepositorySet defines model for a Red Hat repository set. A repository set is the product
+// content from which the repositories for each release and architecture are enabled.
+type RepositorySet struct {
+	ContentURL   *string                    `json:"contentUrl"`
+	Enabled      *bool                      `json:"enabled"`
+	GPGURL       *string                    `json:"gpgUrl"`
+	ID           *int                       `json:"id"`
+	Label        *string                    `json:"label"`
+	Name         *string                    `json:"name"`
+	Product      *genericShortRef           `json:"product"`
+	Repositories *[]RepositorySetRepository `json:"repositories"`
+	Type         *string                    `json:"type"`
+	Vendor       *string                    `json:"vendor"`
+}
+
+// RepositorySetRepository defines model for a repository enabled from a repository set.
+type RepositorySetRepository struct {
+	Arch        *string `json:"arch"`
+	ContentType *string `json:"content_type"`
+	ID          *int    `json:"id"`
+	Label       *string `json:"label"`
+	Name        *string `json:"name"`
+	Releasever  *string `json:"releasever"`
+}
+
+// RepositorySetAvailableRepository defines model for a repository that can be enabled
+// from a repository set for a given architecture and release.
+type RepositorySetAvailableRepository struct {
+	Enabled       *bool                      `json:"enabled"`
+	Name          *string                    `json:"name"`
+	Path          *string                    `json:"path"`
+	Promoted      *bool                      `json:"promoted"`
+	RegistryName  *string                    `json:"registry_name"`
+	RepoName      *string                    `json:"repo_name"`
+	Substitutions *RepositorySetSubstitution `json:"substitutions"`
+}
+
+// RepositorySetSubstitution defines model for the architecture and release of a repository of a repository set.
+type RepositorySetSubstitution struct {
+	Basearch   *string `json:"basearch,omitempty"`
+	Releasever *string `json:"releasever,omitempty"`
+}
+
+// RepositorySetsList defines model for a list of repository sets.
+type RepositorySetsList struct {
+	searchResults
+	Results *[]RepositorySet `json:"results"`
+}
+
+// RepositorySetAvailableRepositoriesList defines model for a list of repositories available in a repository set.
+type RepositorySetAvailableRepositoriesList struct {
+	searchResults
+	Results *[]RepositorySetAvailableRepository `json:"results"`
+}
+
+// RepositorySetsListOptions specifies the optional parameters to various List methods that
+// support pagination.
+type RepositorySetsListOptions struct {
+	KatelloListOptions
+
+	// ID of a product to list repository sets from
+	ProductID int `url:"product_id,omitempty"`
+
+	// ID of an organization to list repository sets from
+	OrganizationID int `url:"organization_id,omitempty"`
+
+	// Repository set name to search on
+	Name string `url:"name,omitempty"`
+
+	// If true, only return repository sets that have been enabled
+	Enabled bool `url:"enabled,omitempty"`
+
+	// If true, only return repository sets that are associated with an active subscription
+	WithActiveSubscription bool `url:"with_active_subscription,omitempty"`
+
+	// If true, return custom repository sets along with redhat repos
+	WithCustom bool `url:"with_custom,omitempty"`
+}
+
+// RepositorySetEnable defines model for enabling or disabling the repository of a repository set
+// for an architecture and release.
+type RepositorySetEnable struct {
+	RepositorySetSubstitution
+	ProductID      *int `json:"product_id,omitempty"`
+	OrganizationID *int `json:"organization_id,omitempty"`
+}
+
+// RepositorySets is an interface for interacting with
+// Red Hat Satellite repository sets
+type RepositorySets interface {
+	Disable(ctx context.Context, setID int, setDisable RepositorySetEnable) (*Task, *http.Response, error)
+	Enable(ctx context.Context, setID int, setEnable RepositorySetEnable) (*Task, *http.Response, error)
+	Get(ctx context.Context, setID int, opt *RepositorySetsListOptions) (*RepositorySet, *http.Response, error)
+	List(ctx context.Context, opt *RepositorySetsListOptions) (*RepositorySetsList, *http.Response, error)
+	ListAvailableRepositories(ctx context.Context, setID int, opt *RepositorySetsListOptions) (*RepositorySetAvailableRepositoriesList, *http.Response, error)
+	ListByProductID(ctx context.Context, productID int, opt *RepositorySetsListOptions) (*RepositorySetsList, *http.Response, error)
+}
+
+// RepositorySetsOp handles communication with the Repository Set related methods of the
+// Red Hat Satellite REST API
+type RepositorySetsOp struct {
+	client *Client
+}
+
+// Disable the repository of a repository set for an architecture and release
+func (s *RepositorySetsOp) Disable(ctx context.Context, setID int, setDisable RepositorySetEnable) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/disable", repositorySetsPath, setID)
+
+	return s.taskRequest(ctx, path, setDisable)
+}
+
+// Enable the repository of a repository set for an architecture and release. The enabled
+// repository can be retrieved from the returned task with RepositorySetTaskRepository.
+func (s *RepositorySetsOp) Enable(ctx context.Context, setID int, setEnable RepositorySetEnable) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/enable", repositorySetsPath, setID)
+
+	return s.taskRequest(ctx, path, setEnable)
+}
+
+// Get a single repository set by its ID
+func (s *RepositorySetsOp) Get(ctx context.Context, setID int, opt *RepositorySetsListOptions) (*RepositorySet, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", repositorySetsPath, setID)
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	set := new(RepositorySet)
+	resp, err := s.client.Do(ctx, req, set)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return set, resp, err
+}
+
+// Performs a list request given a path.
+func (s *RepositorySetsOp) list(ctx context.Context, path string) (*RepositorySetsList, *http.Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(RepositorySetsList)
+	resp, err := s.client.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, err
+}
+
+// List all repository sets or a filtered list of repository sets
+func (s *RepositorySetsOp) List(ctx context.Context, opt *RepositorySetsListOptions) (*RepositorySetsList, *http.Response, error) {
+	path := repositorySetsPath
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.list(ctx, path)
+}
+
+// ListAvailableRepositories lists the architecture and release combinations that can be enabled for a repository set
+func (s *RepositorySetsOp) ListAvailableRepositories(ctx context.Context, setID int, opt *RepositorySetsListOptions) (*RepositorySetAvailableRepositoriesList, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/available_repositories", repositorySetsPath, setID)
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(RepositorySetAvailableRepositoriesList)
+	resp, err := s.client.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, err
+}
+
+// ListByProductID all repository sets of a product or a filtered list of its repository sets
+func (s *RepositorySetsOp) ListByProductID(ctx context.Context, productID int, opt *RepositorySetsListOptions) (*RepositorySetsList, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/repository_sets", productsPath, productID)
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.list(ctx, path)
+}
+
+// Performs an enable or disable request given a path.
+func (s *RepositorySetsOp) taskRequest(ctx context.Context, path string, body RepositorySetEnable) (*Task, *http.Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodPut, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	task := new(Task)
+	resp, err := s.client.Do(ctx, req, task)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return task, resp, err
+}
+
+// RepositorySetTaskRepository returns the repository that was enabled or disabled by the task
+// returned from RepositorySets.Enable or RepositorySets.Disable.
+func RepositorySetTaskRepository(task *Task) (*RepositorySetRepository, error) {
+	if task == nil || task.Output == nil {
+		return nil, NewArgError("task", "has no output")
+	}
+
+	var output struct {
+		Repository *RepositorySetRepository `json:"repository"`
+	}
+
+	if err := json.Unmarshal(*task.Output, &output); err != nil {
+		return nil, err
+	}
+
+	if output.Repository == nil {
+		return nil, NewArgError("task", "has no repository in its output")
+	}
+
+	return output.Repository, nil
+}
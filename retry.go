@@ -0,0 +1,217 @@
+package gosatellite
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryMinBackoff = 1 * time.Second
+	defaultRetryMaxBackoff = 30 * time.Second
+)
+
+// RetryPolicy defines how failed requests to the Red Hat Satellite API are retried.
+// A nil RetryPolicy, or one with MaxAttempts less than 2, disables retries.
+type RetryPolicy struct {
+	// Maximum number of attempts for a request, including the first one
+	MaxAttempts int
+
+	// Backoff before the first retry. It is doubled for every following retry. Defaults to 1 second.
+	MinBackoff time.Duration
+
+	// Upper bound of the backoff between two attempts, including waits requested by the
+	// Retry-After header of a response. Defaults to 30 seconds.
+	MaxBackoff time.Duration
+
+	// HTTP status codes that are retried. Defaults to 429, 502, 503 and 504.
+	RetryableStatusCodes []int
+
+	// Also retry requests that are not idempotent (POST and PATCH). Only enable this if
+	// the calls being made are safe to repeat.
+	RetryNonIdempotent bool
+}
+
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// doWithRetry submits a request, retrying it according to the retry policy of the client.
+// The request completion callback is called for every attempt that received a response.
+func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Response, error) {
+	policy := c.Config.RetryPolicy
+
+	for attempt := 1; ; attempt++ {
+		// Requests without a body, e.g. GET requests, have no GetBody and are resent as is
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		// Credentials such as OAuth signatures are only valid once, so every retry is
+		// authenticated again
+		if attempt > 1 {
+			if err := c.reauthenticate(req); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := DoRequestWithClient(ctx, c.client, req)
+		if err == nil {
+			if o, ok := c.authenticator().(responseObserver); ok {
+				o.observeResponse(resp)
+			}
+			if c.onRequestCompleted != nil {
+				c.onRequestCompleted(req, resp)
+			}
+		}
+
+		if !policy.shouldRetry(ctx, req, resp, err, attempt) {
+			return resp, err
+		}
+
+		wait := policy.backoff(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp); ok {
+				wait = after
+				if max := policy.maxBackoff(); wait > max {
+					wait = max
+				}
+			}
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reauthenticate removes the credentials set on a request by the authenticator of the
+// client and authenticates it again
+func (c *Client) reauthenticate(req *http.Request) error {
+	req.Header.Del("Authorization")
+
+	cookies := req.Cookies()
+	req.Header.Del("Cookie")
+	for _, cookie := range cookies {
+		if cookie.Name != sessionCookieName {
+			req.AddCookie(cookie)
+		}
+	}
+
+	return c.authenticate(req)
+}
+
+// shouldRetry reports whether another attempt should be made after the given attempt
+func (p *RetryPolicy) shouldRetry(ctx context.Context, req *http.Request, resp *http.Response, err error, attempt int) bool {
+	if p == nil || attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+
+	if !p.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return false
+	}
+
+	// The body of the request can only be sent again if it can be recreated. A request
+	// without a body is always safe to resend.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	codes := p.RetryableStatusCodes
+	if codes == nil {
+		codes = defaultRetryableStatusCodes
+	}
+
+	for _, code := range codes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns the exponential backoff with jitter to wait after the given attempt
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	min := p.MinBackoff
+	if min <= 0 {
+		min = defaultRetryMinBackoff
+	}
+
+	max := p.maxBackoff()
+
+	wait := min
+	for i := 1; i < attempt && wait < max; i++ {
+		wait *= 2
+	}
+
+	if wait > max {
+		wait = max
+	}
+
+	// Wait somewhere between half and the full backoff so that concurrent clients spread out
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// maxBackoff returns the upper bound of the wait between two attempts
+func (p *RetryPolicy) maxBackoff() time.Duration {
+	if p.MaxBackoff <= 0 {
+		return defaultRetryMaxBackoff
+	}
+
+	return p.MaxBackoff
+}
+
+// retryAfter parses the Retry-After header of a response, which is either a number
+// of seconds or an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
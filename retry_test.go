@@ -0,0 +1,176 @@
+package gosatellite
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client sending its requests to the handler
+func newTestClient(t *testing.T, handler http.HandlerFunc, policy *RetryPolicy) *Client {
+	t.Helper()
+
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&Config{
+		SatelliteHost: strings.TrimPrefix(server.URL, "https://"),
+		Username:      "admin",
+		Password:      "changeme",
+		HTTPClient:    server.Client(),
+		RetryPolicy:   policy,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func TestDoRetriesGET(t *testing.T) {
+	attempts := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id":1}`))
+	}, &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "/api/organizations/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	org := new(Organization)
+	if _, err := client.Do(context.Background(), req, org); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	if attempts != 2 {
+		t.Errorf("got %d attempts, want 2", attempts)
+	}
+	if org.ID == nil || *org.ID != 1 {
+		t.Errorf("got organization %v, want ID 1", org.ID)
+	}
+}
+
+func TestDoRetriesBody(t *testing.T) {
+	var bodies []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}, &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond})
+
+	req, err := client.NewRequest(context.Background(), http.MethodPut, "/api/organizations/1", map[string]string{"name": "org"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[0] == "" {
+		t.Errorf("got bodies %q, want the same body sent twice", bodies)
+	}
+}
+
+func TestDoStopsAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "/api/organizations", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.Do(context.Background(), req, nil)
+	if err == nil {
+		t.Fatal("Do returned no error")
+	}
+	if resp == nil || resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("got response %v, want status 503", resp)
+	}
+	if attempts != 3 {
+		t.Errorf("got %d attempts, want 3", attempts)
+	}
+}
+
+func TestDoReauthenticatesRetries(t *testing.T) {
+	var authorizations []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		if len(authorizations) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}, &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond})
+	client.Config.Authenticator = &OAuth1{ConsumerKey: "key", ConsumerSecret: "secret"}
+
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "/api/organizations", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	if len(authorizations) != 2 {
+		t.Fatalf("got %d attempts, want 2", len(authorizations))
+	}
+	for _, authorization := range authorizations {
+		if !strings.HasPrefix(authorization, "OAuth ") {
+			t.Errorf("got authorization %q, want an OAuth signature", authorization)
+		}
+	}
+	if authorizations[0] == authorizations[1] {
+		t.Error("the retry was sent with the signature of the first attempt")
+	}
+}
+
+func TestDoCapsRetryAfter(t *testing.T) {
+	attempts := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "7200")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}, &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond})
+
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "/api/organizations", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("got %d attempts, want 2", attempts)
+	}
+}
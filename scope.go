@@ -0,0 +1,233 @@
+package gosatellite
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// requestScope is the organization and location context of a scoped client. A nil
+// field is not part of the context.
+type requestScope struct {
+	organizationID *int
+	locationID     *int
+}
+
+// WithOrganization returns a copy of the client scoped to an organization. Every request
+// of the returned client carries the organization_id parameter, which Foreman and Katello
+// use to set the organization context and to filter the results of list requests. Requests
+// for another organization, through the organization_id parameter, an /organizations/:id
+// path or an organization_id, organization_ids or default_organization_id field of the body,
+// are refused. The original client is not modified.
+func (c *Client) WithOrganization(orgID int) *Client {
+	scope := c.scope.clone()
+	scope.organizationID = &orgID
+
+	return c.withScope(scope)
+}
+
+// WithLocation returns a copy of the client scoped to a location. Every request of the
+// returned client carries the location_id parameter, and requests for another location are
+// refused like for WithOrganization. The original client is not modified.
+func (c *Client) WithLocation(locationID int) *Client {
+	scope := c.scope.clone()
+	scope.locationID = &locationID
+
+	return c.withScope(scope)
+}
+
+// withScope returns a copy of the client with its services bound to the copy
+func (c *Client) withScope(scope *requestScope) *Client {
+	scoped := *c
+	scoped.scope = scope
+
+	scoped.headers = make(map[string]string, len(c.headers))
+	for k, v := range c.headers {
+		scoped.headers[k] = v
+	}
+
+	scoped.setServices()
+
+	return &scoped
+}
+
+func (s *requestScope) clone() *requestScope {
+	if s == nil {
+		return new(requestScope)
+	}
+
+	scope := *s
+	return &scope
+}
+
+// apply adds the context parameters of the scope to the query of u. A request that
+// already sets one of the parameters to another value, or whose path is below another
+// organization or location, e.g. /katello/api/organizations/2/environments, is refused so
+// that a scoped client never reaches outside of its context.
+func (s *requestScope) apply(u *url.URL) error {
+	if s == nil {
+		return nil
+	}
+
+	query := u.Query()
+
+	set := func(name string, id *int) error {
+		if id == nil {
+			return nil
+		}
+
+		value := strconv.Itoa(*id)
+		if existing, ok := query[name]; ok {
+			for _, v := range existing {
+				if err := checkScope(name, v, id); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		query.Set(name, value)
+		return nil
+	}
+
+	if err := set("organization_id", s.organizationID); err != nil {
+		return err
+	}
+	if err := set("location_id", s.locationID); err != nil {
+		return err
+	}
+
+	segments := strings.Split(u.Path, "/")
+	for i := 0; i+1 < len(segments); i++ {
+		var err error
+		switch segments[i] {
+		case "organizations":
+			err = checkScope("organization ID in the path", segments[i+1], s.organizationID)
+		case "locations":
+			err = checkScope("location ID in the path", segments[i+1], s.locationID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	u.RawQuery = query.Encode()
+	return nil
+}
+
+// checkBody refuses a JSON request body which sets one of the organization or location
+// fields of the scope, at any depth, to another value than the one of the scope
+func (s *requestScope) checkBody(body []byte) error {
+	if s == nil || len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return err
+	}
+
+	return s.checkValue(v)
+}
+
+// checkFields refuses form fields which set one of the organization or location fields of
+// the scope to another value than the one of the scope
+func (s *requestScope) checkFields(fields map[string]string) error {
+	if s == nil {
+		return nil
+	}
+
+	for key, value := range fields {
+		if id, ok := s.fieldScope(key); ok {
+			if err := checkScope(key, value, id); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// fieldScope returns the scope ID a body field must match, e.g. the organization of the
+// scope for organization_id, default_organization_id and organization_ids
+func (s *requestScope) fieldScope(key string) (*int, bool) {
+	switch key {
+	case "organization_id", "organization_ids", "default_organization_id":
+		return s.organizationID, true
+	case "location_id", "location_ids", "default_location_id":
+		return s.locationID, true
+	}
+
+	return nil, false
+}
+
+func (s *requestScope) checkValue(v interface{}) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			var err error
+			if id, ok := s.fieldScope(key); ok {
+				err = checkScopeValue(key, value, id)
+			} else {
+				err = s.checkValue(value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, value := range v {
+			if err := s.checkValue(value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkScopeValue checks the JSON value of a body field holding one or a list of IDs. IDs
+// can be sent as numbers or strings.
+func checkScopeValue(name string, value interface{}, id *int) error {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case json.Number:
+		return checkScope(name, value.String(), id)
+	case string:
+		return checkScope(name, value, id)
+	case []interface{}:
+		for _, v := range value {
+			if err := checkScopeValue(name, v, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if id == nil {
+		return nil
+	}
+
+	return NewArgError(name, "must be an ID or a list of IDs")
+}
+
+// checkScope returns an error when value is not the ID the client is scoped to. Any value
+// is accepted when the client is not scoped by this ID.
+func checkScope(name, value string, id *int) error {
+	if id == nil {
+		return nil
+	}
+
+	scoped := strconv.Itoa(*id)
+	if value != scoped {
+		return NewArgError(name, fmt.Sprintf("is %s but the client is scoped to %s", value, scoped))
+	}
+
+	return nil
+}
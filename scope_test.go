@@ -0,0 +1,147 @@
+package gosatellite
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestScopedClientRequests(t *testing.T) {
+	var queries []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Write([]byte(`{}`))
+	}, nil)
+	scoped := client.WithOrganization(1)
+	ctx := context.Background()
+
+	if _, _, err := scoped.ContentViews.ListByOrganizationID(ctx, 1, nil); err != nil {
+		t.Errorf("listing the scoped organization returned error: %v", err)
+	}
+
+	if _, _, err := scoped.ContentViews.Create(ctx, ContentViewCreate{OrganizationID: Int(1), Name: String("cv")}); err != nil {
+		t.Errorf("creating in the scoped organization returned error: %v", err)
+	}
+
+	if len(queries) != 2 || queries[0] != "organization_id=1" || queries[1] != "organization_id=1" {
+		t.Errorf("got queries %q, want organization_id=1 on every request", queries)
+	}
+
+	if _, _, err := client.ContentViews.ListByOrganizationID(ctx, 2, nil); err != nil {
+		t.Errorf("the unscoped client returned error: %v", err)
+	}
+}
+
+func TestScopedClientAcceptsOwnIDsInBody(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{}`))
+	}, nil)
+	scoped := client.WithOrganization(1).WithLocation(3)
+	ctx := context.Background()
+
+	var update UserUpdate
+	update.User.OrganizationIDs = &[]int{1}
+	update.User.LocationIDs = &[]int{}
+	update.User.DefaultOrganizationID = Int(1)
+	update.User.DefaultLocationID = Int(3)
+	if _, _, err := scoped.Users.Update(ctx, 1, update); err != nil {
+		t.Errorf("Update returned error: %v", err)
+	}
+
+	req, err := scoped.NewRequest(ctx, http.MethodPost, "/api/hosts", map[string]interface{}{
+		"host": map[string]interface{}{"organization_id": "1", "location_ids": []string{"3"}},
+	})
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	if _, err := scoped.Do(ctx, req, nil); err != nil {
+		t.Errorf("Do returned error: %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("got %d requests, want 2", requests)
+	}
+}
+
+func TestScopedClientRefusesOtherOrganization(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL)
+	}, nil)
+	scoped := client.WithOrganization(1).WithLocation(3)
+	ctx := context.Background()
+
+	tests := map[string]func() error{
+		"query": func() error {
+			_, _, err := scoped.ContentViews.List(ctx, &ContentViewsListOptions{OrganizationID: 2})
+			return err
+		},
+		"path": func() error {
+			_, _, err := scoped.ContentViews.ListByOrganizationID(ctx, 2, nil)
+			return err
+		},
+		"location path": func() error {
+			_, _, err := scoped.AuthSourceLDAPs.ListByLocationID(ctx, 4, nil)
+			return err
+		},
+		"body": func() error {
+			_, _, err := scoped.ContentViews.Create(ctx, ContentViewCreate{OrganizationID: Int(2), Name: String("cv")})
+			return err
+		},
+		"organization list in body": func() error {
+			var update UserUpdate
+			update.User.OrganizationIDs = &[]int{1, 2}
+			_, _, err := scoped.Users.Update(ctx, 1, update)
+			return err
+		},
+		"location list in body": func() error {
+			var create UserCreate
+			create.User.Login = String("user")
+			create.User.AuthSourceID = Int(1)
+			create.User.LocationIDs = &[]int{4}
+			_, _, err := scoped.Users.Create(ctx, create)
+			return err
+		},
+		"default organization in body": func() error {
+			var update UserUpdate
+			update.User.DefaultOrganizationID = Int(2)
+			_, _, err := scoped.Users.Update(ctx, 1, update)
+			return err
+		},
+		"default location in body": func() error {
+			var update UserUpdate
+			update.User.DefaultLocationID = Int(4)
+			_, _, err := scoped.Users.Update(ctx, 1, update)
+			return err
+		},
+		"string ID in body": func() error {
+			req, err := scoped.NewRequest(ctx, http.MethodPost, "/api/hosts", map[string]interface{}{
+				"host": map[string]interface{}{"organization_id": "2"},
+			})
+			if err == nil {
+				_, err = scoped.Do(ctx, req, nil)
+			}
+			return err
+		},
+		"string IDs in body": func() error {
+			req, err := scoped.NewRequest(ctx, http.MethodPost, "/api/usergroups", map[string]interface{}{
+				"usergroup": map[string]interface{}{"organization_ids": []string{"1", "2"}},
+			})
+			if err == nil {
+				_, err = scoped.Do(ctx, req, nil)
+			}
+			return err
+		},
+	}
+
+	for name, request := range tests {
+		err := request()
+
+		var argErr *ArgError
+		if !errors.As(err, &argErr) {
+			t.Errorf("%s: got error %v, want an *ArgError", name, err)
+		}
+	}
+}
@@ -0,0 +1,348 @@
+package gosatellite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const subscriptionsPath = katelloBasePath + "/subscriptions"
+
+// Subscription defines model for a Subscription of an organization, i.e. a local pool
+// created from the entitlements of its manifest.
+type Subscription struct {
+	AccountNumber      *string                        `json:"account_number"`
+	Available          *int                           `json:"available"`
+	Consumed           *int                           `json:"consumed"`
+	ContractNumber     *string                        `json:"contract_number"`
+	Cores              *int                           `json:"cores"`
+	CpID               *string                        `json:"cp_id"`
+	EndDate            *string                        `json:"end_date"`
+	Host               *genericShortRef               `json:"host"`
+	Hypervisor         *bool                          `json:"hypervisor"`
+	ID                 *int                           `json:"id"`
+	InstanceMultiplier *int                           `json:"instance_multiplier"`
+	MultiEntitlement   *bool                          `json:"multi_entitlement"`
+	Name               *string                        `json:"name"`
+	ProductHostCount   *int                           `json:"product_host_count"`
+	ProductID          *string                        `json:"product_id"`
+	ProductName        *string                        `json:"product_name"`
+	ProvidedProducts   *[]subscriptionProvidedProduct `json:"provided_products"`
+	Quantity           *int                           `json:"quantity"`
+	RAM                *int                           `json:"ram"`
+	Sockets            *int                           `json:"sockets"`
+	StackingID         *string                        `json:"stacking_id"`
+	StartDate          *string                        `json:"start_date"`
+	SubscriptionID     *int                           `json:"subscription_id"`
+	SupportLevel       *string                        `json:"support_level"`
+	Type               *string                        `json:"type"`
+	UnmappedGuest      *bool                          `json:"unmapped_guest"`
+	Upstream           *bool                          `json:"upstream"`
+	UpstreamPoolID     *string                        `json:"upstream_pool_id"`
+	VirtOnly           *bool                          `json:"virt_only"`
+	VirtWho            *bool                          `json:"virt_who"`
+}
+
+type subscriptionProvidedProduct struct {
+	AvailableContent *[]struct {
+		Content *struct {
+			ContentURL *string `json:"contentUrl"`
+			GPGURL     *string `json:"gpgUrl"`
+			ID         *string `json:"id"`
+			Label      *string `json:"label"`
+			Name       *string `json:"name"`
+			Type       *string `json:"type"`
+			Vendor     *string `json:"vendor"`
+		} `json:"content"`
+		Enabled *bool `json:"enabled"`
+	} `json:"available_content"`
+	ID   *string `json:"id"`
+	Name *string `json:"name"`
+}
+
+// SubscriptionsList defines model for a list of subscriptions.
+type SubscriptionsList struct {
+	searchResults
+	Results *[]Subscription `json:"results"`
+}
+
+// SubscriptionsListOptions specifies the optional parameters to various List methods that
+// support pagination.
+type SubscriptionsListOptions struct {
+	KatelloListOptions
+
+	// Subscription name to search on
+	Name string `url:"name,omitempty"`
+
+	// Ignore subscriptions that are unavailable to the specified host
+	MatchHost bool `url:"match_host,omitempty"`
+
+	// Ignore subscriptions not applicable to installed products
+	MatchInstalled bool `url:"match_installed,omitempty"`
+
+	// Return subscriptions which do not overlap with a currently-attached subscription
+	NoOverlap bool `url:"no_overlap,omitempty"`
+}
+
+// UpstreamSubscription defines model for an entitlement of the upstream subscription
+// allocation of an organization.
+type UpstreamSubscription struct {
+	Available      *int    `json:"available"`
+	Capacity       *int    `json:"capacity"`
+	Consumed       *int    `json:"consumed"`
+	ContractNumber *string `json:"contract_number"`
+	EndDate        *string `json:"end_date"`
+	ID             *string `json:"id"`
+	LocalPoolIDs   *[]int  `json:"local_pool_ids"`
+	ProductID      *string `json:"product_id"`
+	ProductName    *string `json:"product_name"`
+	Quantity       *int    `json:"quantity"`
+	StartDate      *string `json:"start_date"`
+	SubscriptionID *string `json:"subscription_id"`
+}
+
+// UpstreamSubscriptionsList defines model for a list of upstream subscriptions.
+type UpstreamSubscriptionsList struct {
+	searchResults
+	Results *[]UpstreamSubscription `json:"results"`
+}
+
+// UpstreamSubscriptionsListOptions specifies the optional parameters to
+// Subscriptions.ListUpstream.
+type UpstreamSubscriptionsListOptions struct {
+	KatelloListOptions
+
+	// Only return the upstream pools with these IDs
+	PoolIDs []string `url:"pool_ids,omitempty,brackets"`
+
+	// Only return the quantities of the pools
+	QuantitiesOnly bool `url:"quantities_only,omitempty"`
+
+	// Only return the pools that can be attached to the allocation
+	Attachable bool `url:"attachable,omitempty"`
+}
+
+// UpstreamSubscriptionQuantity defines model for the quantity of an upstream pool to add to
+// or set on the subscription allocation of an organization.
+type UpstreamSubscriptionQuantity struct {
+	PoolID   string `json:"id"`
+	Quantity int    `json:"quantity"`
+}
+
+// Subscriptions is an interface for interacting with
+// Red Hat Satellite Subscriptions
+type Subscriptions interface {
+	AddUpstream(ctx context.Context, orgID int, pools []UpstreamSubscriptionQuantity) (*Task, *http.Response, error)
+	Get(ctx context.Context, orgID int, subscriptionID int) (*Subscription, *http.Response, error)
+	List(ctx context.Context, orgID int, opt *SubscriptionsListOptions) (*SubscriptionsList, *http.Response, error)
+	ListAvailableForActivationKey(ctx context.Context, akID int, opt *SubscriptionsListOptions) (*SubscriptionsList, *http.Response, error)
+	ListAvailableForHost(ctx context.Context, hostID int, opt *SubscriptionsListOptions) (*SubscriptionsList, *http.Response, error)
+	ListUpstream(ctx context.Context, orgID int, opt *UpstreamSubscriptionsListOptions) (*UpstreamSubscriptionsList, *http.Response, error)
+	RemoveUpstream(ctx context.Context, orgID int, poolIDs []string) (*Task, *http.Response, error)
+	UpdateUpstream(ctx context.Context, orgID int, pools []UpstreamSubscriptionQuantity) (*Task, *http.Response, error)
+}
+
+// SubscriptionsOp handles communication with the Subscription related methods of the
+// Red Hat Satellite REST API
+type SubscriptionsOp struct {
+	client *Client
+}
+
+// AddUpstream adds entitlements of upstream pools to the subscription allocation of an
+// organization. The returned task refreshes the manifest of the organization.
+func (s *SubscriptionsOp) AddUpstream(ctx context.Context, orgID int, pools []UpstreamSubscriptionQuantity) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/upstream_subscriptions", katelloOrganizationsPath, orgID)
+
+	if err := validateUpstreamQuantities(pools); err != nil {
+		return nil, nil, err
+	}
+
+	var body struct {
+		Pools []UpstreamSubscriptionQuantity `json:"pools"`
+	}
+
+	body.Pools = pools
+
+	return s.taskRequest(ctx, http.MethodPost, path, body)
+}
+
+// Get a single subscription of an organization by its ID
+func (s *SubscriptionsOp) Get(ctx context.Context, orgID int, subscriptionID int) (*Subscription, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/subscriptions/%d", katelloOrganizationsPath, orgID, subscriptionID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	subscription := new(Subscription)
+	resp, err := s.client.Do(ctx, req, subscription)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return subscription, resp, err
+}
+
+// Performs a list request given a path.
+func (s *SubscriptionsOp) list(ctx context.Context, path string) (*SubscriptionsList, *http.Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(SubscriptionsList)
+	resp, err := s.client.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, err
+}
+
+// List the subscriptions of an organization
+func (s *SubscriptionsOp) List(ctx context.Context, orgID int, opt *SubscriptionsListOptions) (*SubscriptionsList, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/subscriptions", katelloOrganizationsPath, orgID)
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.list(ctx, path)
+}
+
+// ListAvailableForActivationKey lists the subscriptions that can be attached to an activation key
+func (s *SubscriptionsOp) ListAvailableForActivationKey(ctx context.Context, akID int, opt *SubscriptionsListOptions) (*SubscriptionsList, *http.Response, error) {
+	var query struct {
+		SubscriptionsListOptions
+		ActivationKeyID int    `url:"activation_key_id"`
+		AvailableFor    string `url:"available_for"`
+	}
+
+	if opt != nil {
+		query.SubscriptionsListOptions = *opt
+	}
+	query.ActivationKeyID = akID
+	query.AvailableFor = "activation_key"
+
+	path, err := addOptions(subscriptionsPath, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.list(ctx, path)
+}
+
+// ListAvailableForHost lists the subscriptions that can be attached to a host
+func (s *SubscriptionsOp) ListAvailableForHost(ctx context.Context, hostID int, opt *SubscriptionsListOptions) (*SubscriptionsList, *http.Response, error) {
+	var query struct {
+		SubscriptionsListOptions
+		HostID       int    `url:"host_id"`
+		AvailableFor string `url:"available_for"`
+	}
+
+	if opt != nil {
+		query.SubscriptionsListOptions = *opt
+	}
+	query.HostID = hostID
+	query.AvailableFor = "host"
+
+	path, err := addOptions(subscriptionsPath, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.list(ctx, path)
+}
+
+// ListUpstream lists the entitlements of the upstream subscription allocation of an organization
+func (s *SubscriptionsOp) ListUpstream(ctx context.Context, orgID int, opt *UpstreamSubscriptionsListOptions) (*UpstreamSubscriptionsList, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/upstream_subscriptions", katelloOrganizationsPath, orgID)
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(UpstreamSubscriptionsList)
+	resp, err := s.client.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, err
+}
+
+// RemoveUpstream removes entitlements of upstream pools from the subscription allocation of
+// an organization. The returned task refreshes the manifest of the organization.
+func (s *SubscriptionsOp) RemoveUpstream(ctx context.Context, orgID int, poolIDs []string) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/upstream_subscriptions", katelloOrganizationsPath, orgID)
+
+	if len(poolIDs) < 1 {
+		return nil, nil, NewArgError("poolIDs", "cannot be empty")
+	}
+
+	var body struct {
+		PoolIDs []string `json:"pool_ids"`
+	}
+
+	body.PoolIDs = poolIDs
+
+	return s.taskRequest(ctx, http.MethodDelete, path, body)
+}
+
+// Performs a request given a path that returns a task.
+func (s *SubscriptionsOp) taskRequest(ctx context.Context, method, path string, body interface{}) (*Task, *http.Response, error) {
+	req, err := s.client.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	task := new(Task)
+	resp, err := s.client.Do(ctx, req, task)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return task, resp, err
+}
+
+// UpdateUpstream sets the quantity of entitlements of upstream pools already in the
+// subscription allocation of an organization. The returned task refreshes the manifest of
+// the organization.
+func (s *SubscriptionsOp) UpdateUpstream(ctx context.Context, orgID int, pools []UpstreamSubscriptionQuantity) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/upstream_subscriptions", katelloOrganizationsPath, orgID)
+
+	if err := validateUpstreamQuantities(pools); err != nil {
+		return nil, nil, err
+	}
+
+	var body struct {
+		Pools []UpstreamSubscriptionQuantity `json:"pools"`
+	}
+
+	body.Pools = pools
+
+	return s.taskRequest(ctx, http.MethodPut, path, body)
+}
+
+func validateUpstreamQuantities(pools []UpstreamSubscriptionQuantity) error {
+	if len(pools) < 1 {
+		return NewArgError("pools", "cannot be empty")
+	}
+
+	for _, pool := range pools {
+		if pool.PoolID == "" {
+			return NewArgError("pool.PoolID", "cannot be empty")
+		}
+		if pool.Quantity < 1 {
+			return NewArgError("pool.Quantity", "must be at least 1")
+		}
+	}
+
+	return nil
+}
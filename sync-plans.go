@@ -0,0 +1,406 @@
+package gosatellite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Intervals of sync plans
+const (
+	SyncPlanIntervalHourly     = "hourly"
+	SyncPlanIntervalDaily      = "daily"
+	SyncPlanIntervalWeekly     = "weekly"
+	SyncPlanIntervalCustomCron = "custom cron"
+)
+
+// Layouts the sync date of a sync plan can be formatted with
+var syncDateLayouts = []string{
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05 -0700",
+	time.RFC3339,
+	"2006-01-02T15:04:05.000Z07:00",
+}
+
+// SyncPlan defines model for a Sync Plan.
+type SyncPlan struct {
+	CreatedAt        *string            `json:"created_at"`
+	CronExpression   *string            `json:"cron_expression"`
+	Description      *string            `json:"description"`
+	Enabled          *bool              `json:"enabled"`
+	ID               *int               `json:"id"`
+	Interval         *string            `json:"interval"`
+	Name             *string            `json:"name"`
+	NextSync         *string            `json:"next_sync"`
+	OrganizationID   *int               `json:"organization_id"`
+	Permissions      *spPermissions     `json:"permissions"`
+	ProductIDs       *[]int             `json:"product_ids"`
+	Products         *[]genericShortRef `json:"products"`
+	RecurringLogicID *int               `json:"recurring_logic_id"`
+	SyncDate         *string            `json:"sync_date"`
+	UpdatedAt        *string            `json:"updated_at"`
+}
+
+type spPermissions struct {
+	DestroySyncPlans *bool `json:"destroy_sync_plans"`
+	EditSyncPlans    *bool `json:"edit_sync_plans"`
+	SyncPlans        *bool `json:"sync_plans"`
+	ViewSyncPlans    *bool `json:"view_sync_plans"`
+}
+
+// NextRun computes the first run of the sync plan after the given time from its sync date,
+// interval and cron expression, without asking the server. Daily, weekly and custom cron
+// plans run at the same wall clock time in the location of after, which should be the time
+// zone of the server, so that runs do not drift across daylight saving time changes. The
+// zero time is returned for a disabled sync plan.
+func (p *SyncPlan) NextRun(after time.Time) (time.Time, error) {
+	if p.Enabled != nil && !*p.Enabled {
+		return time.Time{}, nil
+	}
+
+	if p.SyncDate == nil {
+		return time.Time{}, NewArgError("SyncDate", "cannot be empty")
+	}
+
+	start, err := parseSyncDate(*p.SyncDate)
+	if err != nil {
+		return time.Time{}, err
+	}
+	start = start.In(after.Location())
+
+	if p.Interval == nil {
+		return time.Time{}, NewArgError("Interval", "cannot be empty")
+	}
+
+	var days int
+	switch *p.Interval {
+	case SyncPlanIntervalHourly:
+		if after.Before(start) {
+			return start, nil
+		}
+		runs := after.Sub(start)/time.Hour + 1
+		return start.Add(runs * time.Hour), nil
+	case SyncPlanIntervalDaily:
+		days = 1
+	case SyncPlanIntervalWeekly:
+		days = 7
+	case SyncPlanIntervalCustomCron:
+		if p.CronExpression == nil {
+			return time.Time{}, NewArgError("CronExpression", "cannot be empty for a custom cron interval")
+		}
+		schedule, err := parseCron(*p.CronExpression)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		// The cron schedule only applies from the sync date onwards. next returns the first
+		// match after the minute of from, so a sync date on a minute boundary is moved back
+		// one minute to match itself, and one with seconds is truncated to its minute.
+		from := after
+		if from.Before(start) {
+			from = start.Truncate(time.Minute)
+			if from.Equal(start) {
+				from = from.Add(-time.Minute)
+			}
+		}
+
+		next, ok := schedule.next(from)
+		if !ok {
+			return time.Time{}, fmt.Errorf("cron expression %q never matches", *p.CronExpression)
+		}
+		return next, nil
+	default:
+		return time.Time{}, NewArgError("Interval", fmt.Sprintf("unknown interval %q", *p.Interval))
+	}
+
+	if after.Before(start) {
+		return start, nil
+	}
+
+	// Days are not always 24 hours long, so the estimate from the elapsed time may be one
+	// run short and is adjusted by adding calendar days
+	runs := int(after.Sub(start) / (time.Duration(days) * 24 * time.Hour))
+	next := start.AddDate(0, 0, runs*days)
+	for !next.After(after) {
+		runs++
+		next = start.AddDate(0, 0, runs*days)
+	}
+
+	return next, nil
+}
+
+func parseSyncDate(date string) (time.Time, error) {
+	var err error
+	for _, layout := range syncDateLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, date); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, err
+}
+
+// SyncPlansList defines model for a list of sync plans.
+type SyncPlansList struct {
+	searchResults
+	Results *[]SyncPlan `json:"results"`
+}
+
+// SyncPlansListOptions specifies the optional parameters to various List methods that
+// support pagination.
+type SyncPlansListOptions struct {
+	KatelloListOptions
+
+	// Filter by name
+	Name string `url:"name,omitempty"`
+
+	// Filter by sync date
+	SyncDate string `url:"sync_date,omitempty"`
+
+	// Filter by interval
+	Interval string `url:"interval,omitempty"`
+
+	// Filter by enabled state
+	Enabled *bool `url:"enabled,omitempty"`
+}
+
+// SyncPlanCreate defines model for creating a sync plan.
+type SyncPlanCreate struct {
+	Name           *string `json:"name"`
+	Description    *string `json:"description,omitempty"`
+	Interval       *string `json:"interval"`
+	SyncDate       *string `json:"sync_date"`
+	Enabled        *bool   `json:"enabled"`
+	CronExpression *string `json:"cron_expression,omitempty"`
+}
+
+// SyncPlanUpdate defines model for updating a sync plan.
+type SyncPlanUpdate struct {
+	Name           *string `json:"name,omitempty"`
+	Description    *string `json:"description,omitempty"`
+	Interval       *string `json:"interval,omitempty"`
+	SyncDate       *string `json:"sync_date,omitempty"`
+	Enabled        *bool   `json:"enabled,omitempty"`
+	CronExpression *string `json:"cron_expression,omitempty"`
+}
+
+// validateSyncPlanInterval checks that interval is a known interval and that a valid cron
+// expression is given for, and only for, custom cron intervals.
+func validateSyncPlanInterval(arg string, interval *string, cronExpression *string) error {
+	if interval == nil {
+		if cronExpression != nil {
+			if _, err := parseCron(*cronExpression); err != nil {
+				return NewArgError(arg+".CronExpression", err.Error())
+			}
+		}
+		return nil
+	}
+
+	switch *interval {
+	case SyncPlanIntervalHourly, SyncPlanIntervalDaily, SyncPlanIntervalWeekly:
+		if cronExpression != nil && *cronExpression != "" {
+			return NewArgError(arg+".CronExpression", "can only be set with the custom cron interval")
+		}
+	case SyncPlanIntervalCustomCron:
+		if cronExpression == nil || *cronExpression == "" {
+			return NewArgError(arg+".CronExpression", "cannot be empty with the custom cron interval")
+		}
+		if _, err := parseCron(*cronExpression); err != nil {
+			return NewArgError(arg+".CronExpression", err.Error())
+		}
+	default:
+		return NewArgError(arg+".Interval", "must be one of hourly, daily, weekly or custom cron")
+	}
+
+	return nil
+}
+
+// SyncPlans is an interface for interacting with
+// Red Hat Satellite sync plans
+type SyncPlans interface {
+	AddProducts(ctx context.Context, orgID int, syncPlanID int, productIDs []int) (*SyncPlan, *http.Response, error)
+	Create(ctx context.Context, orgID int, spCreate SyncPlanCreate) (*SyncPlan, *http.Response, error)
+	Delete(ctx context.Context, orgID int, syncPlanID int) (*http.Response, error)
+	Disable(ctx context.Context, orgID int, syncPlanID int) (*SyncPlan, *http.Response, error)
+	Enable(ctx context.Context, orgID int, syncPlanID int) (*SyncPlan, *http.Response, error)
+	Get(ctx context.Context, orgID int, syncPlanID int) (*SyncPlan, *http.Response, error)
+	List(ctx context.Context, orgID int, opt *SyncPlansListOptions) (*SyncPlansList, *http.Response, error)
+	RemoveProducts(ctx context.Context, orgID int, syncPlanID int, productIDs []int) (*SyncPlan, *http.Response, error)
+	Sync(ctx context.Context, orgID int, syncPlanID int) (*Task, *http.Response, error)
+	Update(ctx context.Context, orgID int, syncPlanID int, spUpdate SyncPlanUpdate) (*SyncPlan, *http.Response, error)
+}
+
+// SyncPlansOp handles communication with the Sync Plan related methods of the
+// Red Hat Satellite REST API
+type SyncPlansOp struct {
+	client *Client
+}
+
+// AddProducts to a sync plan
+func (s *SyncPlansOp) AddProducts(ctx context.Context, orgID int, syncPlanID int, productIDs []int) (*SyncPlan, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/sync_plans/%d/add_products", katelloOrganizationsPath, orgID, syncPlanID)
+
+	return s.productsRequest(ctx, path, productIDs)
+}
+
+// Create a new sync plan in an organization
+func (s *SyncPlansOp) Create(ctx context.Context, orgID int, spCreate SyncPlanCreate) (*SyncPlan, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/sync_plans", katelloOrganizationsPath, orgID)
+
+	if spCreate.Name == nil {
+		return nil, nil, NewArgError("spCreate.Name", "cannot be empty")
+	} else if *spCreate.Name == "" {
+		return nil, nil, NewArgError("spCreate.Name", "cannot be empty")
+	}
+
+	if spCreate.Interval == nil {
+		return nil, nil, NewArgError("spCreate.Interval", "cannot be empty")
+	}
+
+	if spCreate.SyncDate == nil {
+		return nil, nil, NewArgError("spCreate.SyncDate", "cannot be empty")
+	}
+
+	if spCreate.Enabled == nil {
+		return nil, nil, NewArgError("spCreate.Enabled", "cannot be empty")
+	}
+
+	if err := validateSyncPlanInterval("spCreate", spCreate.Interval, spCreate.CronExpression); err != nil {
+		return nil, nil, err
+	}
+
+	return s.syncPlanRequest(ctx, http.MethodPost, path, spCreate)
+}
+
+// Delete a sync plan by its ID
+func (s *SyncPlansOp) Delete(ctx context.Context, orgID int, syncPlanID int) (*http.Response, error) {
+	path := fmt.Sprintf("%s/%d/sync_plans/%d", katelloOrganizationsPath, orgID, syncPlanID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, err
+}
+
+// Disable a sync plan
+func (s *SyncPlansOp) Disable(ctx context.Context, orgID int, syncPlanID int) (*SyncPlan, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/sync_plans/%d", katelloOrganizationsPath, orgID, syncPlanID)
+
+	return s.syncPlanRequest(ctx, http.MethodPut, path, SyncPlanUpdate{Enabled: Bool(false)})
+}
+
+// Enable a sync plan
+func (s *SyncPlansOp) Enable(ctx context.Context, orgID int, syncPlanID int) (*SyncPlan, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/sync_plans/%d", katelloOrganizationsPath, orgID, syncPlanID)
+
+	return s.syncPlanRequest(ctx, http.MethodPut, path, SyncPlanUpdate{Enabled: Bool(true)})
+}
+
+// Get a single sync plan by its ID
+func (s *SyncPlansOp) Get(ctx context.Context, orgID int, syncPlanID int) (*SyncPlan, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/sync_plans/%d", katelloOrganizationsPath, orgID, syncPlanID)
+
+	return s.syncPlanRequest(ctx, http.MethodGet, path, nil)
+}
+
+// List all sync plans of an organization or a filtered list of sync plans
+func (s *SyncPlansOp) List(ctx context.Context, orgID int, opt *SyncPlansListOptions) (*SyncPlansList, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/sync_plans", katelloOrganizationsPath, orgID)
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	syncPlans := new(SyncPlansList)
+	resp, err := s.client.Do(ctx, req, syncPlans)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return syncPlans, resp, err
+}
+
+// Performs an add or remove products request given a path.
+func (s *SyncPlansOp) productsRequest(ctx context.Context, path string, productIDs []int) (*SyncPlan, *http.Response, error) {
+	if len(productIDs) < 1 {
+		return nil, nil, NewArgError("productIDs", "cannot be empty")
+	}
+
+	var body struct {
+		ProductIDs []int `json:"product_ids"`
+	}
+
+	body.ProductIDs = productIDs
+
+	return s.syncPlanRequest(ctx, http.MethodPut, path, body)
+}
+
+// RemoveProducts from a sync plan
+func (s *SyncPlansOp) RemoveProducts(ctx context.Context, orgID int, syncPlanID int, productIDs []int) (*SyncPlan, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/sync_plans/%d/remove_products", katelloOrganizationsPath, orgID, syncPlanID)
+
+	return s.productsRequest(ctx, path, productIDs)
+}
+
+// Sync all the products of a sync plan now
+func (s *SyncPlansOp) Sync(ctx context.Context, orgID int, syncPlanID int) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/sync_plans/%d/sync", katelloOrganizationsPath, orgID, syncPlanID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodPut, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	task := new(Task)
+	resp, err := s.client.Do(ctx, req, task)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return task, resp, err
+}
+
+// Performs a request given a path that returns a sync plan.
+func (s *SyncPlansOp) syncPlanRequest(ctx context.Context, method, path string, body interface{}) (*SyncPlan, *http.Response, error) {
+	req, err := s.client.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	syncPlan := new(SyncPlan)
+	resp, err := s.client.Do(ctx, req, syncPlan)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return syncPlan, resp, err
+}
+
+// Update a sync plan
+func (s *SyncPlansOp) Update(ctx context.Context, orgID int, syncPlanID int, spUpdate SyncPlanUpdate) (*SyncPlan, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/sync_plans/%d", katelloOrganizationsPath, orgID, syncPlanID)
+
+	if spUpdate.Name != nil && *spUpdate.Name == "" {
+		return nil, nil, NewArgError("spUpdate.Name", "cannot be an empty string")
+	}
+
+	if err := validateSyncPlanInterval("spUpdate", spUpdate.Interval, spUpdate.CronExpression); err != nil {
+		return nil, nil, err
+	}
+
+	return s.syncPlanRequest(ctx, http.MethodPut, path, spUpdate)
+}
@@ -0,0 +1,122 @@
+package gosatellite
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSyncPlanNextRun(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	date := func(month time.Month, day, hour, min, sec int) time.Time {
+		return time.Date(2024, month, day, hour, min, sec, 0, newYork)
+	}
+
+	tests := []struct {
+		name     string
+		interval string
+		cron     string
+		syncDate string
+		after    time.Time
+		want     time.Time
+	}{
+		{
+			name:     "hourly",
+			interval: SyncPlanIntervalHourly,
+			syncDate: "2024-01-01 10:15:00 -0500",
+			after:    date(1, 2, 7, 20, 0),
+			want:     date(1, 2, 8, 15, 0),
+		},
+		{
+			name:     "before the sync date",
+			interval: SyncPlanIntervalDaily,
+			syncDate: "2024-01-01 10:15:00 -0500",
+			after:    date(1, 1, 0, 0, 0),
+			want:     date(1, 1, 10, 15, 0),
+		},
+		{
+			name:     "daily",
+			interval: SyncPlanIntervalDaily,
+			syncDate: "2024-01-01 10:15:00 -0500",
+			after:    date(1, 5, 10, 15, 0),
+			want:     date(1, 6, 10, 15, 0),
+		},
+		{
+			name:     "daily across the start of daylight saving time",
+			interval: SyncPlanIntervalDaily,
+			syncDate: "2024-03-01 10:15:00 -0500",
+			after:    date(3, 20, 9, 0, 0),
+			want:     date(3, 20, 10, 15, 0),
+		},
+		{
+			name:     "daily just after a run across daylight saving time",
+			interval: SyncPlanIntervalDaily,
+			syncDate: "2024-03-01 10:15:00 -0500",
+			after:    date(3, 20, 10, 30, 0),
+			want:     date(3, 21, 10, 15, 0),
+		},
+		{
+			name:     "weekly across the end of daylight saving time",
+			interval: SyncPlanIntervalWeekly,
+			syncDate: "2024-10-01 02:00:00 UTC",
+			after:    date(11, 10, 0, 0, 0),
+			want:     date(11, 11, 22, 0, 0),
+		},
+		{
+			name:     "custom cron",
+			interval: SyncPlanIntervalCustomCron,
+			cron:     "0 3 * * *",
+			syncDate: "2024-01-01 00:00:00 -0500",
+			after:    date(1, 5, 3, 0, 0),
+			want:     date(1, 6, 3, 0, 0),
+		},
+		{
+			name:     "custom cron matching the sync date",
+			interval: SyncPlanIntervalCustomCron,
+			cron:     "0 3 * * *",
+			syncDate: "2024-01-10 03:00:00 -0500",
+			after:    date(1, 1, 0, 0, 0),
+			want:     date(1, 10, 3, 0, 0),
+		},
+		{
+			name:     "custom cron with a sync date within the matching minute",
+			interval: SyncPlanIntervalCustomCron,
+			cron:     "* * * * *",
+			syncDate: "2024-01-10 03:00:30 -0500",
+			after:    date(1, 1, 0, 0, 0),
+			want:     date(1, 10, 3, 1, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &SyncPlan{Interval: String(tt.interval), SyncDate: String(tt.syncDate)}
+			if tt.cron != "" {
+				plan.CronExpression = String(tt.cron)
+			}
+
+			got, err := plan.NextRun(tt.after)
+			if err != nil {
+				t.Fatalf("NextRun returned error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("NextRun(%v) = %v, want %v", tt.after, got, tt.want)
+			}
+			if got.Before(tt.after) {
+				t.Errorf("NextRun(%v) = %v, before the given time", tt.after, got)
+			}
+		})
+	}
+}
+
+func TestSyncPlanNextRunDisabled(t *testing.T) {
+	plan := &SyncPlan{Enabled: Bool(false)}
+
+	got, err := plan.NextRun(time.Now())
+	if err != nil || !got.IsZero() {
+		t.Errorf("NextRun = %v, %v, want the zero time", got, err)
+	}
+}
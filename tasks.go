@@ -0,0 +1,322 @@
+package gosatellite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const tasksPath = foremanTasksBasePath + "/tasks"
+
+const defaultTaskPollInterval = 5 * time.Second
+
+// Task defines model for a Foreman task. Long running Katello actions such as a
+// manifest upload or a content view publish return a task, which is bound to the client
+// that returned it and can be waited on with Wait.
+type Task struct {
+	Action           *string               `json:"action"`
+	AvailableActions *taskAvailableActions `json:"available_actions"`
+	CLIExample       *string               `json:"cli_example"`
+	EndedAt          *string               `json:"ended_at"`
+	Humanized        *taskHumanized        `json:"humanized"`
+	ID               *string               `json:"id"`
+	Input            *json.RawMessage      `json:"input"`
+	Label            *string               `json:"label"`
+	Output           *json.RawMessage      `json:"output"`
+	ParentTaskID     *string               `json:"parent_task_id"`
+	Pending          *bool                 `json:"pending"`
+	Progress         *float64              `json:"progress"`
+	Result           *string               `json:"result"`
+	StartAt          *string               `json:"start_at"`
+	StartBefore      *string               `json:"start_before"`
+	StartedAt        *string               `json:"started_at"`
+	State            *string               `json:"state"`
+	UserName         *string               `json:"username"`
+
+	// Client the task was returned by, used to poll it
+	client *Client
+}
+
+type taskAvailableActions struct {
+	Cancellable *bool `json:"cancellable"`
+	Resumable   *bool `json:"resumable"`
+}
+
+type taskHumanized struct {
+	Action *string          `json:"action"`
+	Errors *[]string        `json:"errors"`
+	Input  *json.RawMessage `json:"input"`
+	Output *string          `json:"output"`
+}
+
+// Finished reports whether the task has stopped running
+func (t *Task) Finished() bool {
+	if t.State == nil {
+		return false
+	}
+
+	return *t.State == "stopped" || *t.State == "paused"
+}
+
+// Wait polls the task until it stops running and returns its final state, like
+// Tasks.WaitForTask. The task must have been returned by a method of the client.
+func (t *Task) Wait(ctx context.Context, pollOpts *TaskPollOptions) (*Task, error) {
+	if t.client == nil {
+		return nil, NewArgError("task", "is not bound to a client, use Tasks.WaitForTask")
+	}
+
+	if t.ID == nil || *t.ID == "" {
+		return nil, NewArgError("task.ID", "cannot be empty")
+	}
+
+	return t.client.Tasks.WaitForTask(ctx, *t.ID, pollOpts)
+}
+
+// bindClient binds the task to the client that decoded it
+func (t *Task) bindClient(c *Client) {
+	t.client = c
+}
+
+// TasksList defines model for a list of tasks.
+type TasksList struct {
+	searchResults
+	Results *[]Task `json:"results"`
+}
+
+// TasksListOptions specifies the optional parameters to various List methods that
+// support pagination.
+type TasksListOptions struct {
+	ListOptions
+
+	// Field to sort the results on
+	SortBy string `url:"sort_by,omitempty"`
+
+	// How to order the sorted results (e.g. ASC for ascending)
+	SortOrder string `url:"sort_order,omitempty"`
+}
+
+// TaskSearch defines model for a single search of a bulk task search.
+type TaskSearch struct {
+	SearchID     *string   `json:"search_id,omitempty"`
+	Type         *string   `json:"type"`
+	TaskID       *string   `json:"task_id,omitempty"`
+	UserID       *int      `json:"user_id,omitempty"`
+	ResourceType *string   `json:"resource_type,omitempty"`
+	ResourceID   *int      `json:"resource_id,omitempty"`
+	ActionTypes  *[]string `json:"action_types,omitempty"`
+	ActiveOnly   *bool     `json:"active_only,omitempty"`
+	Page         *int      `json:"page,omitempty"`
+	PerPage      *int      `json:"per_page,omitempty"`
+}
+
+// TaskSearchResult defines model for the result of a single search of a bulk task search.
+type TaskSearchResult struct {
+	SearchID     *string   `json:"search_id"`
+	Type         *string   `json:"type"`
+	TaskID       *string   `json:"task_id"`
+	UserID       *int      `json:"user_id"`
+	ResourceType *string   `json:"resource_type"`
+	ResourceID   *int      `json:"resource_id"`
+	ActionTypes  *[]string `json:"action_types"`
+	ActiveOnly   *bool     `json:"active_only"`
+	Results      *[]Task   `json:"results"`
+}
+
+// TaskBulkAction defines model for the body of a bulk action on tasks. Either
+// Search or TaskIDs selects the tasks the action applies to.
+type TaskBulkAction struct {
+	Search  *string   `json:"search,omitempty"`
+	TaskIDs *[]string `json:"task_ids,omitempty"`
+}
+
+// TaskBulkActionResult defines model for the result of a bulk action on tasks.
+type TaskBulkActionResult struct {
+	Total     *int    `json:"total"`
+	Cancelled *[]Task `json:"cancelled"`
+	Resumed   *[]Task `json:"resumed"`
+	Failed    *[]Task `json:"failed"`
+	Skipped   *[]Task `json:"skipped"`
+}
+
+// TaskPollOptions specifies how WaitForTask polls a task
+type TaskPollOptions struct {
+	// Time to wait between two polls. Defaults to 5 seconds.
+	Interval time.Duration
+
+	// Return a TaskError when the task finishes with a warning. Defaults to true.
+	FailOnWarning *bool
+}
+
+// Tasks is an interface for interacting with
+// Red Hat Satellite Foreman tasks
+type Tasks interface {
+	BulkResume(ctx context.Context, bulkAction TaskBulkAction) (*TaskBulkActionResult, *http.Response, error)
+	Cancel(ctx context.Context, taskID string) (*TaskBulkActionResult, *http.Response, error)
+	Get(ctx context.Context, taskID string) (*Task, *http.Response, error)
+	List(ctx context.Context, opt *TasksListOptions) (*TasksList, *http.Response, error)
+	Search(ctx context.Context, searches []TaskSearch) (*[]TaskSearchResult, *http.Response, error)
+	WaitForTask(ctx context.Context, taskID string, pollOpts *TaskPollOptions) (*Task, error)
+}
+
+// TasksOp handles communication with the Foreman task related methods of the
+// Red Hat Satellite REST API
+type TasksOp struct {
+	client *Client
+}
+
+// BulkResume resumes the paused tasks matching a search or a list of task IDs
+func (s *TasksOp) BulkResume(ctx context.Context, bulkAction TaskBulkAction) (*TaskBulkActionResult, *http.Response, error) {
+	path := tasksPath + "/bulk_resume"
+
+	return s.bulkAction(ctx, path, bulkAction)
+}
+
+// Cancel a running task by its ID
+func (s *TasksOp) Cancel(ctx context.Context, taskID string) (*TaskBulkActionResult, *http.Response, error) {
+	path := tasksPath + "/bulk_cancel"
+
+	if taskID == "" {
+		return nil, nil, NewArgError("taskID", "cannot be empty")
+	}
+
+	return s.bulkAction(ctx, path, TaskBulkAction{TaskIDs: &[]string{taskID}})
+}
+
+// Performs a bulk action request given a path.
+func (s *TasksOp) bulkAction(ctx context.Context, path string, bulkAction TaskBulkAction) (*TaskBulkActionResult, *http.Response, error) {
+	if bulkAction.Search == nil && bulkAction.TaskIDs == nil {
+		return nil, nil, NewArgError("Both bulkAction.Search and bulkAction.TaskIDs", "cannot be empty")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, bulkAction)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(TaskBulkActionResult)
+	resp, err := s.client.Do(ctx, req, result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result, resp, err
+}
+
+// Get a single task by its ID
+func (s *TasksOp) Get(ctx context.Context, taskID string) (*Task, *http.Response, error) {
+	path := fmt.Sprintf("%s/%s", tasksPath, taskID)
+
+	if taskID == "" {
+		return nil, nil, NewArgError("taskID", "cannot be empty")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	task := new(Task)
+	resp, err := s.client.Do(ctx, req, task)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return task, resp, err
+}
+
+// List all tasks or a filtered list of tasks
+func (s *TasksOp) List(ctx context.Context, opt *TasksListOptions) (*TasksList, *http.Response, error) {
+	path := tasksPath
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tasks := new(TasksList)
+	resp, err := s.client.Do(ctx, req, tasks)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return tasks, resp, err
+}
+
+// Search for tasks by task, user or resource using the bulk search API
+func (s *TasksOp) Search(ctx context.Context, searches []TaskSearch) (*[]TaskSearchResult, *http.Response, error) {
+	path := tasksPath + "/bulk_search"
+
+	if len(searches) < 1 {
+		return nil, nil, NewArgError("searches", "cannot be empty")
+	}
+
+	var body struct {
+		Searches []TaskSearch `json:"searches"`
+	}
+
+	body.Searches = searches
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := new([]TaskSearchResult)
+	resp, err := s.client.Do(ctx, req, results)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return results, resp, err
+}
+
+// WaitForTask polls a task until it stops running and returns its final state. A *TaskError
+// is returned when the task finished with an error or a warning.
+func (s *TasksOp) WaitForTask(ctx context.Context, taskID string, pollOpts *TaskPollOptions) (*Task, error) {
+	interval := defaultTaskPollInterval
+	failOnWarning := true
+	if pollOpts != nil {
+		if pollOpts.Interval > 0 {
+			interval = pollOpts.Interval
+		}
+		if pollOpts.FailOnWarning != nil {
+			failOnWarning = *pollOpts.FailOnWarning
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		task, _, err := s.Get(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+
+		if task.Finished() {
+			if task.Result != nil {
+				switch *task.Result {
+				case "error":
+					return task, &TaskError{Task: task}
+				case "warning":
+					if failOnWarning {
+						return task, &TaskError{Task: task}
+					}
+				}
+			}
+			return task, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return task, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
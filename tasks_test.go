@@ -0,0 +1,109 @@
+package gosatellite
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTaskWait(t *testing.T) {
+	polls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/katello/api/organizations/1/subscriptions/refresh_manifest":
+			w.Write([]byte(`{"id":"abc","state":"running","pending":true}`))
+		case "/foreman_tasks/api/tasks/abc":
+			polls++
+			if polls < 2 {
+				w.Write([]byte(`{"id":"abc","state":"running","pending":true}`))
+				return
+			}
+			w.Write([]byte(`{"id":"abc","state":"stopped","result":"error","humanized":{"errors":["manifest expired"]}}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}, nil)
+
+	task, _, err := client.Manifests.Refresh(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	finished, err := task.Wait(context.Background(), &TaskPollOptions{Interval: time.Millisecond})
+
+	var taskErr *TaskError
+	if !errors.As(err, &taskErr) {
+		t.Fatalf("got error %v, want a *TaskError", err)
+	}
+	if finished == nil || !finished.Finished() {
+		t.Errorf("got task %v, want a finished task", finished)
+	}
+	if polls != 2 {
+		t.Errorf("got %d polls, want 2", polls)
+	}
+}
+
+func TestTaskWaitUnbound(t *testing.T) {
+	task := &Task{ID: String("abc")}
+
+	if _, err := task.Wait(context.Background(), nil); err == nil {
+		t.Error("Wait on a task not returned by a client returned no error")
+	}
+}
+
+func TestTaskErrorWithoutTask(t *testing.T) {
+	err := &TaskError{}
+
+	if err.Error() == "" {
+		t.Error("Error returned an empty message")
+	}
+}
+
+func TestTaskWaitNested(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/foreman_tasks/api/tasks":
+			w.Write([]byte(`{"results":[{"id":"abc","state":"running","pending":true}]}`))
+		case "/foreman_tasks/api/tasks/bulk_search":
+			w.Write([]byte(`[{"search_id":"1","results":[{"id":"abc","state":"running","pending":true}]}]`))
+		case "/katello/api/content_view_versions/1":
+			w.Write([]byte(`{"id":1,"active_history":[{"id":2,"task":{"id":"abc","state":"running","pending":true}}]}`))
+		case "/foreman_tasks/api/tasks/abc":
+			w.Write([]byte(`{"id":"abc","state":"stopped","result":"success"}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}, nil)
+	ctx := context.Background()
+
+	list, _, err := client.Tasks.List(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	searches, _, err := client.Tasks.Search(ctx, []TaskSearch{{Type: String("task"), TaskID: String("abc")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cvv, _, err := client.ContentViewVersions.Get(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tasks := map[string]*Task{
+		"list":    &(*list.Results)[0],
+		"search":  &(*(*searches)[0].Results)[0],
+		"history": (*cvv.ActiveHistory)[0].Task,
+	}
+	for name, task := range tasks {
+		finished, err := task.Wait(ctx, &TaskPollOptions{Interval: time.Millisecond})
+		if err != nil {
+			t.Errorf("%s: Wait returned error: %v", name, err)
+			continue
+		}
+		if !finished.Finished() {
+			t.Errorf("%s: got task %v, want a finished task", name, finished)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package gosatellite
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultDialTimeout         = 30 * time.Second
+	defaultTLSHandshakeTimeout = 10 * time.Second
+	defaultIdleConnTimeout     = 90 * time.Second
+)
+
+// newHTTPClient builds the HTTP client used to talk to the Red Hat Satellite API from
+// the given configuration. The client owns its transport so that the TLS settings never
+// leak into other HTTP clients of the process.
+func newHTTPClient(config *Config) (*http.Client, error) {
+	if config.HTTPClient != nil {
+		return config.HTTPClient, nil
+	}
+
+	tlsConfig, err := newTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if config.ProxyURL != "" {
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			return nil, NewArgError("config.ProxyURL", err.Error())
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	transport := &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   defaultDialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       defaultIdleConnTimeout,
+		TLSHandshakeTimeout:   defaultTLSHandshakeTimeout,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       tlsConfig,
+	}
+
+	return &http.Client{Transport: transport, Timeout: config.Timeout}, nil
+}
+
+// newTLSConfig builds the TLS configuration for the Red Hat Satellite server from the
+// CA bundle, client certificate and SSLVerify settings of config.
+func newTLSConfig(config *Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: !config.SSLVerify,
+	}
+
+	if config.CACertFile != "" || len(config.CACert) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if config.CACertFile != "" {
+			pem, err := ioutil.ReadFile(config.CACertFile)
+			if err != nil {
+				return nil, err
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, NewArgError("config.CACertFile", "does not contain any PEM encoded certificates")
+			}
+		}
+
+		if len(config.CACert) > 0 {
+			if !pool.AppendCertsFromPEM(config.CACert) {
+				return nil, NewArgError("config.CACert", "does not contain any PEM encoded certificates")
+			}
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	switch {
+	case config.ClientCertFile != "" || config.ClientKeyFile != "":
+		if config.ClientCertFile == "" || config.ClientKeyFile == "" {
+			return nil, NewArgError("config.ClientCertFile and config.ClientKeyFile", "must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(config.ClientCertFile, config.ClientKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+
+	case len(config.ClientCert) > 0 || len(config.ClientKey) > 0:
+		cert, err := tls.X509KeyPair(config.ClientCert, config.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,618 @@
+package gosatellite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	stdsort "sort"
+	"strconv"
+	"strings"
+)
+
+const usersPath = basePath + "/users"
+
+// User defines model for a User.
+type User struct {
+	Admin               *bool               `json:"admin"`
+	AuthSourceID        *int                `json:"auth_source_id"`
+	AuthSourceName      *string             `json:"auth_source_name"`
+	AuthSourceType      *string             `json:"auth_source_type"`
+	CachedUserGroups    *[]genericUserGroup `json:"cached_usergroups"`
+	CreatedAt           *string             `json:"created_at"`
+	DefaultLocation     *genericReference   `json:"default_location"`
+	DefaultOrganization *genericReference   `json:"default_organization"`
+	Description         *string             `json:"description"`
+	Disabled            *bool               `json:"disabled"`
+	EffectiveAdmin      *bool               `json:"effective_admin"`
+	Firstname           *string             `json:"firstname"`
+	ID                  *int                `json:"id"`
+	LastLoginOn         *string             `json:"last_login_on"`
+	Lastname            *string             `json:"lastname"`
+	Locale              *string             `json:"locale"`
+	Locations           *[]genericReference `json:"locations"`
+	Login               *string             `json:"login"`
+	Mail                *string             `json:"mail"`
+	MailEnabled         *bool               `json:"mail_enabled"`
+	Organizations       *[]genericReference `json:"organizations"`
+	Roles               *[]genericRole      `json:"roles"`
+	Timezone            *string             `json:"timezone"`
+	UpdatedAt           *string             `json:"updated_at"`
+	UserGroups          *[]genericUserGroup `json:"usergroups"`
+}
+
+// UsersList defines model for a list of users.
+type UsersList struct {
+	searchResults
+	Results *[]User `json:"results"`
+}
+
+// UsersListOptions specifies the optional parameters to various List methods that
+// support pagination.
+type UsersListOptions struct {
+	ListOptions
+
+	// Scope by LDAP authentication source
+	AuthSourceLDAPID int `url:"auth_source_ldap_id,omitempty"`
+
+	// Scope by locations
+	LocationID int `url:"location_id,omitempty"`
+
+	// Scope by organizations
+	OrganizationID int `url:"organization_id,omitempty"`
+
+	// Scope by role
+	RoleID int `url:"role_id,omitempty"`
+
+	// Scope by user group
+	UserGroupID int `url:"usergroup_id,omitempty"`
+}
+
+// UserCreate defines model for the body of the creation of a user.
+type UserCreate struct {
+	User struct {
+		Login                 *string `json:"login"`
+		AuthSourceID          *int    `json:"auth_source_id"`
+		Admin                 *bool   `json:"admin,omitempty"`
+		DefaultLocationID     *int    `json:"default_location_id,omitempty"`
+		DefaultOrganizationID *int    `json:"default_organization_id,omitempty"`
+		Description           *string `json:"description,omitempty"`
+		Disabled              *bool   `json:"disabled,omitempty"`
+		Firstname             *string `json:"firstname,omitempty"`
+		Lastname              *string `json:"lastname,omitempty"`
+		Locale                *string `json:"locale,omitempty"`
+		LocationIDs           *[]int  `json:"location_ids,omitempty"`
+		Mail                  *string `json:"mail,omitempty"`
+		MailEnabled           *bool   `json:"mail_enabled,omitempty"`
+		OrganizationIDs       *[]int  `json:"organization_ids,omitempty"`
+		Password              *string `json:"password,omitempty"`
+		RoleIDs               *[]int  `json:"role_ids,omitempty"`
+		Timezone              *string `json:"timezone,omitempty"`
+	} `json:"user"`
+}
+
+// UserUpdate defines model for the body of the update of a user.
+type UserUpdate struct {
+	User struct {
+		Login                 *string `json:"login,omitempty"`
+		AuthSourceID          *int    `json:"auth_source_id,omitempty"`
+		Admin                 *bool   `json:"admin,omitempty"`
+		CurrentPassword       *string `json:"current_password,omitempty"`
+		DefaultLocationID     *int    `json:"default_location_id,omitempty"`
+		DefaultOrganizationID *int    `json:"default_organization_id,omitempty"`
+		Description           *string `json:"description,omitempty"`
+		Disabled              *bool   `json:"disabled,omitempty"`
+		Firstname             *string `json:"firstname,omitempty"`
+		Lastname              *string `json:"lastname,omitempty"`
+		Locale                *string `json:"locale,omitempty"`
+		LocationIDs           *[]int  `json:"location_ids,omitempty"`
+		Mail                  *string `json:"mail,omitempty"`
+		MailEnabled           *bool   `json:"mail_enabled,omitempty"`
+		OrganizationIDs       *[]int  `json:"organization_ids,omitempty"`
+		Password              *string `json:"password,omitempty"`
+		RoleIDs               *[]int  `json:"role_ids,omitempty"`
+		Timezone              *string `json:"timezone,omitempty"`
+	} `json:"user"`
+}
+
+// UserMailNotification defines model for a mail notification a user is subscribed to.
+type UserMailNotification struct {
+	Description      *string `json:"description"`
+	ID               *int    `json:"id"`
+	Interval         *string `json:"interval"`
+	MailQuery        *string `json:"mail_query"`
+	Name             *string `json:"name"`
+	SubscriptionType *string `json:"subscription_type"`
+}
+
+// UserMailNotificationsList defines model for a list of the mail notifications of a user.
+type UserMailNotificationsList struct {
+	searchResults
+	Results *[]UserMailNotification `json:"results"`
+}
+
+// UserMailNotificationCreate defines model for the body of the subscription of a user
+// to a mail notification.
+type UserMailNotificationCreate struct {
+	MailNotificationID *int    `json:"mail_notification_id"`
+	Interval           *string `json:"interval,omitempty"`
+	MailQuery          *string `json:"mail_query,omitempty"`
+	Subscription       *string `json:"subscription,omitempty"`
+}
+
+// UserMailNotificationUpdate defines model for the body of the update of the subscription
+// of a user to a mail notification.
+type UserMailNotificationUpdate struct {
+	Interval     *string `json:"interval,omitempty"`
+	MailQuery    *string `json:"mail_query,omitempty"`
+	Subscription *string `json:"subscription,omitempty"`
+}
+
+// UserPermissions defines model for the effective permissions of a user, i.e. the
+// permissions granted by the roles of the user and of the user groups it belongs to.
+type UserPermissions struct {
+	// Whether the user is an administrator, directly or through a user group. An
+	// administrator is granted every permission regardless of its filters.
+	Admin bool
+
+	// Filters of the roles of the user and of its user groups
+	Filters []Filter
+}
+
+// Has returns whether the user is granted a permission, e.g. view_hosts. A permission
+// granted by a filter limited by a search is only granted on the matching resources.
+func (p *UserPermissions) Has(permission string) bool {
+	if p.Admin {
+		return true
+	}
+
+	for _, name := range p.Names() {
+		if name == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Names returns the sorted names of the permissions granted by the filters
+func (p *UserPermissions) Names() []string {
+	seen := make(map[string]bool)
+	names := []string{}
+
+	for _, f := range p.Filters {
+		if f.Permissions == nil {
+			continue
+		}
+		for _, permission := range *f.Permissions {
+			if permission.Name == nil || seen[*permission.Name] {
+				continue
+			}
+			seen[*permission.Name] = true
+			names = append(names, *permission.Name)
+		}
+	}
+
+	stdsort.Strings(names)
+	return names
+}
+
+// filtersList defines model for a list of filters.
+type filtersList struct {
+	searchResults
+	Results *[]Filter `json:"results"`
+}
+
+// Users is an interface for interacting with
+// Red Hat Satellite users
+type Users interface {
+	AddMailNotification(ctx context.Context, userID int, notificationCreate UserMailNotificationCreate) (*UserMailNotification, *http.Response, error)
+	AddRole(ctx context.Context, userID int, roleID int) (*User, *http.Response, error)
+	AddToUserGroup(ctx context.Context, userID int, userGroupID int) (*UserGroup, *http.Response, error)
+	Create(ctx context.Context, userCreate UserCreate) (*User, *http.Response, error)
+	Delete(ctx context.Context, userID int) (*http.Response, error)
+	EffectivePermissions(ctx context.Context, userID int) (*UserPermissions, *http.Response, error)
+	Get(ctx context.Context, userID int) (*User, *http.Response, error)
+	List(ctx context.Context, opt *UsersListOptions) (*UsersList, *http.Response, error)
+	ListMailNotifications(ctx context.Context, userID int) (*UserMailNotificationsList, *http.Response, error)
+	RemoveFromUserGroup(ctx context.Context, userID int, userGroupID int) (*UserGroup, *http.Response, error)
+	RemoveMailNotification(ctx context.Context, userID int, mailNotificationID int) (*http.Response, error)
+	RemoveRole(ctx context.Context, userID int, roleID int) (*User, *http.Response, error)
+	Update(ctx context.Context, userID int, userUpdate UserUpdate) (*User, *http.Response, error)
+	UpdateMailNotification(ctx context.Context, userID int, mailNotificationID int, notificationUpdate UserMailNotificationUpdate) (*UserMailNotification, *http.Response, error)
+}
+
+// UsersOp handles communication with the User related methods of the
+// Red Hat Satellite REST API
+type UsersOp struct {
+	client *Client
+}
+
+// AddMailNotification subscribes a user to a mail notification
+func (s *UsersOp) AddMailNotification(ctx context.Context, userID int, notificationCreate UserMailNotificationCreate) (*UserMailNotification, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/mail_notifications", usersPath, userID)
+
+	if notificationCreate.MailNotificationID == nil {
+		return nil, nil, NewArgError("notificationCreate.MailNotificationID", "cannot be empty")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, notificationCreate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notification := new(UserMailNotification)
+	resp, err := s.client.Do(ctx, req, notification)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return notification, resp, err
+}
+
+// AddRole assigns a role to a user, keeping the roles already assigned to it
+func (s *UsersOp) AddRole(ctx context.Context, userID int, roleID int) (*User, *http.Response, error) {
+	return s.updateRoles(ctx, userID, func(roleIDs []int) []int {
+		for _, id := range roleIDs {
+			if id == roleID {
+				return nil
+			}
+		}
+		return append(roleIDs, roleID)
+	})
+}
+
+// AddToUserGroup adds a user to a user group, keeping the current members of the group.
+// Foreman only updates the members of a group as a whole, so the members are read and then
+// written back: a concurrent change to the members of the same group may be lost.
+func (s *UsersOp) AddToUserGroup(ctx context.Context, userID int, userGroupID int) (*UserGroup, *http.Response, error) {
+	return s.updateUserGroupMembers(ctx, userGroupID, func(userIDs []int) []int {
+		for _, id := range userIDs {
+			if id == userID {
+				return nil
+			}
+		}
+		return append(userIDs, userID)
+	})
+}
+
+// Create a new user
+func (s *UsersOp) Create(ctx context.Context, userCreate UserCreate) (*User, *http.Response, error) {
+	path := usersPath
+
+	if userCreate.User.Login == nil {
+		return nil, nil, NewArgError("userCreate.Login", "cannot be empty")
+	} else if *userCreate.User.Login == "" {
+		return nil, nil, NewArgError("userCreate.Login", "cannot be empty")
+	}
+
+	if userCreate.User.AuthSourceID == nil {
+		return nil, nil, NewArgError("userCreate.AuthSourceID", "cannot be empty")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, userCreate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := new(User)
+	resp, err := s.client.Do(ctx, req, user)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return user, resp, err
+}
+
+// Delete a user by its ID
+func (s *UsersOp) Delete(ctx context.Context, userID int) (*http.Response, error) {
+	path := fmt.Sprintf("%s/%d", usersPath, userID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, err
+}
+
+// EffectivePermissions returns the permissions of a user: the filters of its roles and of
+// the roles of the user groups it belongs to, directly or through nested user groups. The
+// permissions of the built-in Default role, which every user has, are not included.
+//
+// Foreman only returns the roles of a user group when it is fetched on its own, so this
+// makes one request per user group of the user, on top of the request for the user and
+// one request per page of filters.
+func (s *UsersOp) EffectivePermissions(ctx context.Context, userID int) (*UserPermissions, *http.Response, error) {
+	user, resp, err := s.Get(ctx, userID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	permissions := &UserPermissions{
+		Admin: (user.Admin != nil && *user.Admin) || (user.EffectiveAdmin != nil && *user.EffectiveAdmin),
+	}
+
+	roleIDs := make(map[int]bool)
+	addRoles := func(roles *[]genericRole) {
+		if roles == nil {
+			return
+		}
+		for _, role := range *roles {
+			if role.ID != nil {
+				roleIDs[*role.ID] = true
+			}
+		}
+	}
+
+	addRoles(user.Roles)
+
+	if user.CachedUserGroups != nil {
+		for _, group := range *user.CachedUserGroups {
+			if group.ID == nil {
+				continue
+			}
+
+			userGroup, resp, err := s.client.UserGroups.Get(ctx, *group.ID)
+			if err != nil {
+				return nil, resp, err
+			}
+
+			if userGroup.Admin != nil && *userGroup.Admin {
+				permissions.Admin = true
+			}
+			addRoles(userGroup.Roles)
+		}
+	}
+
+	if len(roleIDs) == 0 {
+		return permissions, resp, nil
+	}
+
+	ids := make([]string, 0, len(roleIDs))
+	for id := range roleIDs {
+		ids = append(ids, strconv.Itoa(id))
+	}
+	stdsort.Strings(ids)
+
+	opt := ListOptions{Search: fmt.Sprintf("role_id ^ (%s)", strings.Join(ids, ", "))}
+	fetch := func(ctx context.Context, page int) (Page, *http.Response, error) {
+		opt.Page = page
+
+		path, err := addOptions(filtersPath, opt)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		list := new(filtersList)
+		resp, err = s.client.Do(ctx, req, list)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		return list, resp, err
+	}
+
+	if err := All(ctx, fetch, &permissions.Filters); err != nil {
+		return nil, resp, err
+	}
+
+	return permissions, resp, nil
+}
+
+// Get a single user by its ID
+func (s *UsersOp) Get(ctx context.Context, userID int) (*User, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", usersPath, userID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := new(User)
+	resp, err := s.client.Do(ctx, req, user)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return user, resp, err
+}
+
+// List all users or a filtered list of users
+func (s *UsersOp) List(ctx context.Context, opt *UsersListOptions) (*UsersList, *http.Response, error) {
+	path := usersPath
+	path, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(UsersList)
+	resp, err := s.client.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, err
+}
+
+// ListMailNotifications lists the mail notifications a user is subscribed to
+func (s *UsersOp) ListMailNotifications(ctx context.Context, userID int) (*UserMailNotificationsList, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/mail_notifications", usersPath, userID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := new(UserMailNotificationsList)
+	resp, err := s.client.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list, resp, err
+}
+
+// RemoveFromUserGroup removes a user from a user group, keeping the other members of the group.
+// Like AddToUserGroup, it may lose a concurrent change to the members of the same group.
+func (s *UsersOp) RemoveFromUserGroup(ctx context.Context, userID int, userGroupID int) (*UserGroup, *http.Response, error) {
+	return s.updateUserGroupMembers(ctx, userGroupID, func(userIDs []int) []int {
+		return removeID(userIDs, userID)
+	})
+}
+
+// RemoveMailNotification unsubscribes a user from a mail notification
+func (s *UsersOp) RemoveMailNotification(ctx context.Context, userID int, mailNotificationID int) (*http.Response, error) {
+	path := fmt.Sprintf("%s/%d/mail_notifications/%d", usersPath, userID, mailNotificationID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, err
+}
+
+// RemoveRole unassigns a role from a user, keeping the other roles assigned to it
+func (s *UsersOp) RemoveRole(ctx context.Context, userID int, roleID int) (*User, *http.Response, error) {
+	return s.updateRoles(ctx, userID, func(roleIDs []int) []int {
+		return removeID(roleIDs, roleID)
+	})
+}
+
+// Update a user
+func (s *UsersOp) Update(ctx context.Context, userID int, userUpdate UserUpdate) (*User, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d", usersPath, userID)
+
+	if userUpdate.User.Login != nil && *userUpdate.User.Login == "" {
+		return nil, nil, NewArgError("userUpdate.Login", "cannot be an empty string")
+	}
+
+	req, err := s.client.NewRequest(ctx, http.MethodPut, path, userUpdate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := new(User)
+	resp, err := s.client.Do(ctx, req, user)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return user, resp, err
+}
+
+// UpdateMailNotification updates the subscription of a user to a mail notification
+func (s *UsersOp) UpdateMailNotification(ctx context.Context, userID int, mailNotificationID int, notificationUpdate UserMailNotificationUpdate) (*UserMailNotification, *http.Response, error) {
+	path := fmt.Sprintf("%s/%d/mail_notifications/%d", usersPath, userID, mailNotificationID)
+
+	req, err := s.client.NewRequest(ctx, http.MethodPut, path, notificationUpdate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notification := new(UserMailNotification)
+	resp, err := s.client.Do(ctx, req, notification)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return notification, resp, err
+}
+
+// updateRoles replaces the roles of a user by the ones returned by change, which is called
+// with the IDs of the roles currently assigned. The user is not updated when change
+// returns nil.
+func (s *UsersOp) updateRoles(ctx context.Context, userID int, change func(roleIDs []int) []int) (*User, *http.Response, error) {
+	user, resp, err := s.Get(ctx, userID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	roleIDs := []int{}
+	if user.Roles != nil {
+		for _, role := range *user.Roles {
+			if role.ID != nil {
+				roleIDs = append(roleIDs, *role.ID)
+			}
+		}
+	}
+
+	roleIDs = change(roleIDs)
+	if roleIDs == nil {
+		return user, resp, nil
+	}
+
+	update := UserUpdate{}
+	update.User.RoleIDs = &roleIDs
+
+	return s.Update(ctx, userID, update)
+}
+
+// updateUserGroupMembers replaces the users of a user group by the ones returned by change,
+// which is called with the IDs of the current members. The user group is not updated when
+// change returns nil.
+func (s *UsersOp) updateUserGroupMembers(ctx context.Context, userGroupID int, change func(userIDs []int) []int) (*UserGroup, *http.Response, error) {
+	userGroup, resp, err := s.client.UserGroups.Get(ctx, userGroupID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	userIDs := []int{}
+	if userGroup.Users != nil {
+		for _, user := range *userGroup.Users {
+			if user.ID != nil {
+				userIDs = append(userIDs, *user.ID)
+			}
+		}
+	}
+
+	userIDs = change(userIDs)
+	if userIDs == nil {
+		return userGroup, resp, nil
+	}
+
+	update := UserGroupUpdate{}
+	update.UserGroup.UserIDs = &userIDs
+
+	return s.client.UserGroups.Update(ctx, userGroupID, update)
+}
+
+// removeID returns ids without id, or nil when id is not in ids
+func removeID(ids []int, id int) []int {
+	kept := []int{}
+	found := false
+
+	for _, v := range ids {
+		if v == id {
+			found = true
+			continue
+		}
+		kept = append(kept, v)
+	}
+
+	if !found {
+		return nil
+	}
+
+	return kept
+}
@@ -0,0 +1,158 @@
+package gosatellite
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseSimpleYAML parses the subset of YAML used by hammer configuration files: nested
+// mappings of scalar values, with keys optionally written as Ruby symbols (:key:) and
+// comments. Sequences and multi-line values are skipped. Keys are returned without their
+// leading colon, and nested mappings as map[string]interface{}.
+func parseSimpleYAML(data string) (map[string]interface{}, error) {
+	type level struct {
+		indent int
+		values map[string]interface{}
+	}
+
+	root := make(map[string]interface{})
+	stack := []level{{indent: -1, values: root}}
+
+	// Indentation of the lines to skip, e.g. the items of a sequence, or -1
+	skipIndent := -1
+
+	for n, line := range strings.Split(data, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, fmt.Errorf("line %d: tabs cannot be used for indentation", n+1)
+		}
+
+		indent := len(line) - len(trimmed)
+		if skipIndent >= 0 {
+			if indent > skipIndent || (indent == skipIndent && strings.HasPrefix(trimmed, "- ")) {
+				continue
+			}
+			skipIndent = -1
+		}
+
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			skipIndent = indent
+			continue
+		}
+
+		key, value, err := splitYAMLKeyValue(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", n+1, err)
+		}
+		if strings.HasPrefix(value, "#") {
+			value = ""
+		}
+
+		for len(stack) > 1 && indent <= stack[len(stack)-1].indent {
+			stack = stack[:len(stack)-1]
+		}
+		parent := stack[len(stack)-1].values
+
+		if value == "" {
+			child := make(map[string]interface{})
+			parent[key] = child
+			stack = append(stack, level{indent: indent, values: child})
+			continue
+		}
+
+		if value == "|" || value == ">" || strings.HasPrefix(value, "&") || strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{") {
+			skipIndent = indent
+			continue
+		}
+
+		parent[key], err = parseYAMLScalar(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", n+1, err)
+		}
+	}
+
+	return root, nil
+}
+
+// splitYAMLKeyValue splits a "key: value" or ":key: value" line
+func splitYAMLKeyValue(line string) (string, string, error) {
+	rest := strings.TrimPrefix(line, ":")
+
+	end := strings.Index(rest, ": ")
+	if end < 0 {
+		if !strings.HasSuffix(rest, ":") {
+			return "", "", fmt.Errorf("expected a key followed by a colon")
+		}
+		end = len(rest) - 1
+	}
+
+	key := strings.Trim(rest[:end], `"'`)
+	if key == "" {
+		return "", "", fmt.Errorf("empty key")
+	}
+
+	return key, strings.TrimSpace(rest[end+1:]), nil
+}
+
+// parseYAMLScalar unquotes a scalar value and removes its trailing comment
+func parseYAMLScalar(value string) (string, error) {
+	switch value[0] {
+	case '\'', '"':
+		unquoted, rest, err := unquoteYAML(value)
+		if err != nil {
+			return "", err
+		}
+		if rest = strings.TrimSpace(rest); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", fmt.Errorf("unexpected %s after quoted value", rest)
+		}
+		return unquoted, nil
+	}
+
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+
+	return value, nil
+}
+
+// unquoteYAML unquotes the single or double quoted string value starts with, and returns
+// the rest of value after the closing quote
+func unquoteYAML(value string) (string, string, error) {
+	quote := value[0]
+
+	var b strings.Builder
+	for i := 1; i < len(value); i++ {
+		c := value[i]
+
+		switch {
+		case quote == '\'' && c == '\'':
+			// A single quote is escaped by doubling it
+			if i+1 < len(value) && value[i+1] == '\'' {
+				b.WriteByte('\'')
+				i++
+				continue
+			}
+			return b.String(), value[i+1:], nil
+		case quote == '"' && c == '"':
+			return b.String(), value[i+1:], nil
+		case quote == '"' && c == '\\' && i+1 < len(value):
+			i++
+			switch value[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(value[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", "", fmt.Errorf("unterminated quoted value %s", value)
+}
@@ -0,0 +1,195 @@
+package gosatellite
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSimpleYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want map[string]interface{}
+	}{
+		{
+			name: "hammer foreman module",
+			data: `:foreman:
+  # Enable/disable foreman commands
+  :enable_module: true
+
+  # Your foreman server address
+  :host: 'https://satellite.example.com/'
+
+  # Credentials. You'll be asked for the interactively if you leave them blank here
+  :username: 'admin'
+  :password: 'changeme'
+
+  # Check API documentation cache status on each request
+  #:refresh_cache: false
+
+  # API request timeout in seconds, set -1 for infinity
+  :request_timeout: 120 #seconds
+`,
+			want: map[string]interface{}{
+				"foreman": map[string]interface{}{
+					"enable_module":   "true",
+					"host":            "https://satellite.example.com/",
+					"username":        "admin",
+					"password":        "changeme",
+					"request_timeout": "120",
+				},
+			},
+		},
+		{
+			name: "hammer cli config",
+			data: `---
+:ui:
+  :interactive: true
+  :per_page: 20
+  :history_file: '~/.hammer/history'
+
+:watch_plain: false
+
+:log_dir: '~/.hammer/log'
+:log_level: 'error'
+:log_size: 1 #MB
+
+:ssl:
+  :ssl_ca_file: '/etc/pki/katello/certs/katello-server-ca.crt'
+  :verify_ssl: true
+`,
+			want: map[string]interface{}{
+				"ui": map[string]interface{}{
+					"interactive":  "true",
+					"per_page":     "20",
+					"history_file": "~/.hammer/history",
+				},
+				"watch_plain": "false",
+				"log_dir":     "~/.hammer/log",
+				"log_level":   "error",
+				"log_size":    "1",
+				"ssl": map[string]interface{}{
+					"ssl_ca_file": "/etc/pki/katello/certs/katello-server-ca.crt",
+					"verify_ssl":  "true",
+				},
+			},
+		},
+		{
+			name: "unquoted url",
+			data: ":foreman:\n  :host: https://satellite.example.com\n",
+			want: map[string]interface{}{
+				"foreman": map[string]interface{}{"host": "https://satellite.example.com"},
+			},
+		},
+		{
+			name: "plain keys",
+			data: "host: satellite.example.com\nusername: admin\nssl_verify: false\n",
+			want: map[string]interface{}{
+				"host":       "satellite.example.com",
+				"username":   "admin",
+				"ssl_verify": "false",
+			},
+		},
+		{
+			name: "single quotes",
+			data: `:password: 'it''s # not a comment' # a comment`,
+			want: map[string]interface{}{"password": "it's # not a comment"},
+		},
+		{
+			name: "double quotes",
+			data: `:password: "p\"a\\ss: #word" # a comment`,
+			want: map[string]interface{}{"password": `p"a\ss: #word`},
+		},
+		{
+			name: "empty quoted value",
+			data: `:password: ''`,
+			want: map[string]interface{}{"password": ""},
+		},
+		{
+			name: "nested sections",
+			data: `:a:
+  :b:
+    :c: 1
+    :d:
+      :e: 2
+  :f: 3
+:g: 4
+`,
+			want: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": map[string]interface{}{
+						"c": "1",
+						"d": map[string]interface{}{"e": "2"},
+					},
+					"f": "3",
+				},
+				"g": "4",
+			},
+		},
+		{
+			name: "empty section",
+			data: ":foreman: # nothing yet\n:ssl:\n  :verify_ssl: false\n",
+			want: map[string]interface{}{
+				"foreman": map[string]interface{}{},
+				"ssl":     map[string]interface{}{"verify_ssl": "false"},
+			},
+		},
+		{
+			name: "sequences and block values skipped",
+			data: `:modules:
+  - hammer_cli_foreman
+  - hammer_cli_katello
+:list:
+- a
+- b
+:flow: [a, b]
+:text: |
+  line one
+  line two
+:host: satellite.example.com
+`,
+			want: map[string]interface{}{
+				"modules": map[string]interface{}{},
+				"list":    map[string]interface{}{},
+				"host":    "satellite.example.com",
+			},
+		},
+		{
+			name: "windows line endings",
+			data: ":foreman:\r\n  :username: admin\r\n",
+			want: map[string]interface{}{
+				"foreman": map[string]interface{}{"username": "admin"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSimpleYAML(tt.data)
+			if err != nil {
+				t.Fatalf("parseSimpleYAML returned error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSimpleYAML = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSimpleYAMLErrors(t *testing.T) {
+	tests := map[string]string{
+		"tab indentation":          ":foreman:\n\t:host: satellite\n",
+		"missing colon":            ":foreman:\n  host\n",
+		"empty key":                ": value\n",
+		"unterminated single":      ":password: 'secret\n",
+		"unterminated double":      `:password: "secret`,
+		"text after quoted value":  `:password: 'secret' trailing`,
+		"unterminated with escape": `:password: "secret\"`,
+	}
+
+	for name, data := range tests {
+		if got, err := parseSimpleYAML(data); err == nil {
+			t.Errorf("%s: parseSimpleYAML = %#v, want an error", name, got)
+		}
+	}
+}